@@ -0,0 +1,59 @@
+// Command garbagecollector runs the operator's standalone GarbageCollector: it periodically lists
+// every FlinkApplication in the cluster, enqueues the ones that are terminal and have
+// Spec.TTLSecondsAfterFinished set (delayed by whatever remains of that TTL), and deletes their
+// owned resources once the TTL's delaying queue entry fires.
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+	"github.com/lyft/flinkk8soperator/pkg/controller/gc"
+	"github.com/lyft/flinkk8soperator/pkg/controller/k8"
+)
+
+// pollInterval is how often the cluster is re-scanned for terminal Applications. Every scan lists
+// all Applications and enqueues every terminal one again, including those already enqueued.
+const pollInterval = time.Minute
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	k8Cluster := k8.NewK8Cluster()
+	collector := gc.NewGarbageCollector(k8Cluster, k8Cluster)
+
+	go collector.Run(ctx)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		enqueueFinishedApplications(ctx, k8Cluster, collector)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func enqueueFinishedApplications(ctx context.Context, k8Cluster k8.K8ClusterInterface, collector gc.GarbageCollectorInterface) {
+	apps, err := k8Cluster.ListApplications(ctx)
+	if err != nil {
+		log.Printf("garbagecollector: failed to list applications: %v", err)
+		return
+	}
+	for _, app := range apps.Items {
+		if app.Spec.TTLSecondsAfterFinished == nil {
+			continue
+		}
+		if !gc.FinishedJobStatuses[client.FlinkJobStatus(app.Status.JobStatus.Status)] {
+			continue
+		}
+		collector.Enqueue(app.Namespace, app.Name, app.Status.JobStatus.LastTransitionTime.Time, *app.Spec.TTLSecondsAfterFinished)
+	}
+}
@@ -0,0 +1,193 @@
+// Command manager runs the operator's reconcile loop behind leader election and shard
+// partitioning: only the elected leader for each shard drives CreateCluster for the
+// FlinkApplications that shard owns, and the replica's identity/shard assignment is served at
+// :8080/status.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+	"github.com/lyft/flinkk8soperator/pkg/controller/flink"
+	"github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+	"github.com/lyft/flinkk8soperator/pkg/controller/ha"
+	"github.com/lyft/flinkk8soperator/pkg/controller/k8"
+)
+
+// reconcileInterval is how often the leader re-lists and reconciles the Applications it owns.
+const reconcileInterval = 30 * time.Second
+
+// jobStatusPollInterval is how often the FlinkJobStatusWatcher polls each watched job.
+const jobStatusPollInterval = 10 * time.Second
+
+// lockNamespace and lockName identify the Lease object replicas contend for. Each shard elects
+// its own leader independently off a lock name derived from lockName and shardID (see
+// shardLockName in main), so replicas in different shards never contend for the same Lease.
+const lockNamespace = "flink-operator"
+const lockName = "flink-operator-leader"
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	shardID := envInt("SHARD_ID", 0)
+	totalShards := envInt("TOTAL_SHARDS", 1)
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		identity = "flink-operator-manager"
+	}
+
+	kubeClient, err := newKubeClient()
+	if err != nil {
+		log.Fatalf("manager: failed to build kube client: %v", err)
+	}
+
+	// Each shard elects its own leader: a shared lock name would let only one shard in the
+	// entire fleet ever hold leadership, leaving every other shard's Applications unreconciled.
+	shardLockName := fmt.Sprintf("%s-shard-%d", lockName, shardID)
+	elector, err := ha.NewLeaderElector(kubeClient, lockNamespace, shardLockName, identity)
+	if err != nil {
+		log.Fatalf("manager: failed to build leader elector: %v", err)
+	}
+
+	go func() {
+		if err := http.ListenAndServe(":8080", ha.NewStatusHandler(elector, shardID, totalShards)); err != nil {
+			log.Printf("manager: status server stopped: %v", err)
+		}
+	}()
+
+	k8Cluster := k8.NewK8Cluster()
+	flinkController := flink.NewFlinkController()
+
+	bus := flink.NewEventBus()
+	go logJobEvents(bus)
+	watcher := flink.NewFlinkJobStatusWatcher(client.NewFlinkJobManagerClient(), k8Cluster, bus)
+
+	elector.Run(ctx, func(ctx context.Context) {
+		reconcileOwnedApplications(ctx, k8Cluster, flinkController, watcher, shardID, totalShards)
+	}, func() {
+		log.Printf("manager: %s lost leadership", identity)
+	})
+}
+
+// logJobEvents subscribes to bus and logs every JobEvent the FlinkJobStatusWatcher emits; a real
+// deployment would instead feed these into alerting, but this is enough to exercise the
+// watcher/bus decoupling end to end.
+func logJobEvents(bus flink.EventBus) {
+	for event := range bus.Subscribe() {
+		log.Printf("manager: job event %s for %s/%s (job %s): %s", event.Type, event.Namespace, event.ApplicationName, event.JobId, event.Message)
+	}
+}
+
+func reconcileOwnedApplications(ctx context.Context, k8Cluster k8.K8ClusterInterface, flinkController flink.FlinkInterface, watcher flink.FlinkJobStatusWatcherInterface, shardID, totalShards int) {
+	watched := map[string]bool{}
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+	for {
+		apps, err := k8Cluster.ListApplications(ctx)
+		if err != nil {
+			log.Printf("manager: failed to list applications: %v", err)
+		} else {
+			for _, app := range apps.Items {
+				app := app
+				if !ha.OwnsShard(&app, shardID, totalShards) {
+					continue
+				}
+				changed, err := flinkController.HasApplicationChanged(ctx, &app)
+				if err != nil {
+					log.Printf("manager: failed to check application %s/%s: %v", app.Namespace, app.Name, err)
+					continue
+				}
+				if changed {
+					if err := flinkController.CreateCluster(ctx, &app); err != nil {
+						log.Printf("manager: failed to create cluster for %s/%s: %v", app.Namespace, app.Name, err)
+					}
+					continue
+				}
+				watchActiveJob(ctx, flinkController, watcher, &app, watched)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchActiveJob starts a FlinkJobStatusWatcher goroutine for each of application's jobs the
+// first time it's seen; subsequent reconcile ticks are no-ops for a job already being watched. In
+// Application mode there is exactly one job to watch (the active one); in Session mode the
+// cluster hosts many FlinkJobs at once, so every one of them gets its own watch.
+func watchActiveJob(ctx context.Context, flinkController flink.FlinkInterface, watcher flink.FlinkJobStatusWatcherInterface, app *v1alpha1.FlinkApplication, watched map[string]bool) {
+	appKey := app.Namespace + "/" + app.Name
+	jobs, err := flinkController.GetJobsForApplication(ctx, app)
+	if err != nil {
+		log.Printf("manager: failed to get jobs for application %s: %v", appKey, err)
+		return
+	}
+
+	if app.Spec.DeploymentMode == v1alpha1.DeploymentModeSession {
+		for _, job := range jobs {
+			job := job
+			watchJob(ctx, watcher, app, job.JobId, watched)
+		}
+		return
+	}
+
+	activeJob := flink.GetActiveFlinkJob(jobs)
+	if activeJob == nil {
+		return
+	}
+	watchJob(ctx, watcher, app, activeJob.JobId, watched)
+}
+
+// watchJob starts watching jobId the first time it's seen for application, keyed by
+// (namespace, name, jobId) so that watching one job never stops on or is overwritten by another
+// job on the same application.
+func watchJob(ctx context.Context, watcher flink.FlinkJobStatusWatcherInterface, app *v1alpha1.FlinkApplication, jobId string, watched map[string]bool) {
+	key := app.Namespace + "/" + app.Name + "/" + jobId
+	if watched[key] {
+		return
+	}
+	watched[key] = true
+	go watcher.Watch(ctx, app, jobId, jobStatusPollInterval)
+}
+
+func newKubeClient() (kubernetes.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(),
+			&clientcmd.ConfigOverrides{},
+		).ClientConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+func envInt(key string, fallback int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
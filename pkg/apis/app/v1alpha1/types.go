@@ -0,0 +1,173 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlinkDeploymentMode selects how a FlinkApplication's cluster is shaped.
+type FlinkDeploymentMode string
+
+const (
+	// DeploymentModeApplication is the default mode: one dedicated JobManager/TaskManager
+	// cluster per Application, running exactly one job.
+	DeploymentModeApplication FlinkDeploymentMode = "Application"
+	// DeploymentModeSession hosts many jobs (each a FlinkJob) on a single shared cluster.
+	DeploymentModeSession FlinkDeploymentMode = "Session"
+)
+
+// FlinkApplication is the Schema for the flinkapplications API, representing a single managed
+// Flink cluster and (in Application mode) the one job running on it.
+type FlinkApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlinkApplicationSpec   `json:"spec,omitempty"`
+	Status FlinkApplicationStatus `json:"status,omitempty"`
+
+	// JobJarName is the name of the jar, already present in the JobManager's lib directory,
+	// that StartFlinkJob submits.
+	JobJarName string `json:"jobJarName,omitempty"`
+
+	// SavepointInfo tracks the savepoint used to restart the job across cluster swaps.
+	SavepointInfo SavepointInfo `json:"savepointInfo,omitempty"`
+}
+
+// FlinkApplicationList is a list of FlinkApplications.
+type FlinkApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlinkApplication `json:"items"`
+}
+
+// FlinkApplicationSpec is the desired state of a FlinkApplication.
+type FlinkApplicationSpec struct {
+	Image              string   `json:"image,omitempty"`
+	Parallelism        int32    `json:"parallelism,omitempty"`
+	NumberTaskManagers int32    `json:"numberTaskManagers,omitempty"`
+	Args               []string `json:"args,omitempty"`
+
+	// DeploymentMode selects whether this Application owns a dedicated single-job cluster
+	// (DeploymentModeApplication, the default) or is hosted on a shared session cluster
+	// (DeploymentModeSession). Empty is treated as DeploymentModeApplication.
+	DeploymentMode FlinkDeploymentMode `json:"deploymentMode,omitempty"`
+
+	// TTLSecondsAfterFinished is how long the GarbageCollector waits, after the active job
+	// reaches a terminal state, before deleting this Application and the resources it owns.
+	// Unset disables TTL-based garbage collection.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// OldClusterRetentionSeconds delays DeleteOldCluster by this many seconds after a
+	// deployment is first observed to no longer match the spec, giving a rollback window after
+	// blue/green deploys. Zero deletes old clusters immediately.
+	OldClusterRetentionSeconds int64 `json:"oldClusterRetentionSeconds,omitempty"`
+
+	// SavepointRetention configures pruning of old savepoints from the configured SavepointStore
+	// after a successful restore.
+	SavepointRetention SavepointRetentionPolicy `json:"savepointRetention,omitempty"`
+
+	// AllowNonRestoredState mirrors Flink's --allowNonRestoredState submission flag, for
+	// schema-evolution deploys where the job graph no longer matches every operator in the
+	// checkpoint/savepoint being restored from.
+	AllowNonRestoredState bool `json:"allowNonRestoredState,omitempty"`
+}
+
+// SavepointRetentionPolicy bounds how many savepoints PruneSavepoints keeps.
+type SavepointRetentionPolicy struct {
+	// Count is the number of most-recent savepoints to keep. Zero means unbounded by count.
+	Count int32 `json:"count,omitempty"`
+	// MaxAgeSeconds prunes savepoints older than this. Zero means unbounded by age.
+	MaxAgeSeconds int64 `json:"maxAgeSeconds,omitempty"`
+}
+
+// SavepointInfo tracks an in-flight or most-recently-used savepoint.
+type SavepointInfo struct {
+	TriggerId         string `json:"triggerId,omitempty"`
+	SavepointLocation string `json:"savepointLocation,omitempty"`
+}
+
+// FlinkApplicationStatus is the observed state of a FlinkApplication.
+type FlinkApplicationStatus struct {
+	// ActiveJobId is the id of the single running job in Application mode. Not used in Session
+	// mode, where each FlinkJob tracks its own job id in FlinkJobStatus.JobId.
+	ActiveJobId string `json:"activeJobId,omitempty"`
+
+	JobStatus FlinkJobStatusInfo `json:"jobStatus,omitempty"`
+
+	// VersionHistory records every ApplicationVersion this Application has been promoted to,
+	// oldest first, so RollbackToVersion can locate a prior spec by name.
+	VersionHistory []ApplicationVersion `json:"versionHistory,omitempty"`
+
+	// CurrentVersion is the name of the most recently recorded ApplicationVersion.
+	CurrentVersion string `json:"currentVersion,omitempty"`
+}
+
+// FlinkJobStatusInfo mirrors the subset of a Flink job's REST status the operator tracks on the
+// CR, independent of the flink/client package so the API types have no controller-package
+// dependency.
+type FlinkJobStatusInfo struct {
+	// Status is one of the client.FlinkJobStatus string values (RUNNING, FINISHED, CANCELED,
+	// FAILED, ...).
+	Status string `json:"status,omitempty"`
+	// LastTransitionTime is when Status last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ApplicationVersion is a single recorded, named version of a FlinkApplication's spec: a
+// discrete, monotonically-numbered snapshot of the fields that affect what gets deployed
+// (image, jar, parallelism, args), along with the savepoint that was in place when it was
+// promoted and a hash used to detect no-op spec changes.
+type ApplicationVersion struct {
+	// Name uniquely identifies this version within the Application, e.g. "v3".
+	Name string `json:"name"`
+	// VersionNumber is monotonically increasing, starting at 1.
+	VersionNumber int64 `json:"versionNumber"`
+	// CreatedAt is when this version was recorded.
+	CreatedAt metav1.Time `json:"createdAt"`
+
+	Image       string `json:"image"`
+	JobJarName  string `json:"jobJarName"`
+	Parallelism int32  `json:"parallelism"`
+	// SpecHash is HashApplicationSpec's output for this version's spec fields.
+	SpecHash string `json:"specHash"`
+	// SavepointLocation is the savepoint this version was deployed from, if any.
+	SavepointLocation string `json:"savepointLocation,omitempty"`
+	// Validated is set once ValidateApplicationVersion has successfully dry-run this version.
+	Validated bool `json:"validated"`
+}
+
+// FlinkJob is the Schema for the flinkjobs API: a single job submitted to a session cluster,
+// referencing its parent FlinkApplication (which must be running in DeploymentModeSession) by
+// name.
+type FlinkJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlinkJobSpec   `json:"spec,omitempty"`
+	Status FlinkJobStatus `json:"status,omitempty"`
+}
+
+// FlinkJobList is a list of FlinkJobs.
+type FlinkJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlinkJob `json:"items"`
+}
+
+// FlinkJobSpec is the desired state of a FlinkJob.
+type FlinkJobSpec struct {
+	// SessionClusterName is the name of the parent FlinkApplication, which must have
+	// Spec.DeploymentMode == DeploymentModeSession, that this job is submitted to.
+	SessionClusterName string `json:"sessionClusterName"`
+
+	JobJarName            string        `json:"jobJarName"`
+	Parallelism           int32         `json:"parallelism,omitempty"`
+	SavepointInfo         SavepointInfo `json:"savepointInfo,omitempty"`
+	AllowNonRestoredState bool          `json:"allowNonRestoredState,omitempty"`
+}
+
+// FlinkJobStatus is the observed state of a FlinkJob.
+type FlinkJobStatus struct {
+	// JobId is the Flink-assigned job id on the session cluster, once submitted.
+	JobId  string `json:"jobId,omitempty"`
+	Status string `json:"status,omitempty"`
+}
@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyObject implements runtime.Object so FlinkApplication can be used with a
+// controller-runtime/typed client.
+
+func (in *FlinkApplication) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	if in.Spec.Args != nil {
+		out.Spec.Args = append([]string(nil), in.Spec.Args...)
+	}
+	if in.Spec.TTLSecondsAfterFinished != nil {
+		ttl := *in.Spec.TTLSecondsAfterFinished
+		out.Spec.TTLSecondsAfterFinished = &ttl
+	}
+	if in.Status.VersionHistory != nil {
+		out.Status.VersionHistory = append([]ApplicationVersion(nil), in.Status.VersionHistory...)
+	}
+	return &out
+}
+
+func (in *FlinkApplicationList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.ListMeta = *in.ListMeta.DeepCopy()
+	if in.Items != nil {
+		out.Items = make([]FlinkApplication, len(in.Items))
+		for i := range in.Items {
+			out.Items[i] = *in.Items[i].DeepCopyObject().(*FlinkApplication)
+		}
+	}
+	return &out
+}
+
+func (in *FlinkJob) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	return &out
+}
+
+func (in *FlinkJobList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.ListMeta = *in.ListMeta.DeepCopy()
+	if in.Items != nil {
+		out.Items = make([]FlinkJob, len(in.Items))
+		for i := range in.Items {
+			out.Items[i] = *in.Items[i].DeepCopyObject().(*FlinkJob)
+		}
+	}
+	return &out
+}
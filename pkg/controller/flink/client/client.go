@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const jobManagerPort = 8081
+const requestTimeout = 10 * time.Second
+
+// NewFlinkJobManagerClient returns a FlinkAPIInterface that talks to a JobManager's REST API
+// over plain HTTP on jobManagerPort, using the cluster-local service name as the host.
+func NewFlinkJobManagerClient() FlinkAPIInterface {
+	return &FlinkJobManagerClient{
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type FlinkJobManagerClient struct {
+	httpClient *http.Client
+}
+
+func (c *FlinkJobManagerClient) baseURL(serviceName string) string {
+	return fmt.Sprintf("http://%s:%d", serviceName, jobManagerPort)
+}
+
+func (c *FlinkJobManagerClient) doJSON(ctx context.Context, method, url string, body interface{}, out interface{}) error {
+	var reqBody bytes.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = *bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, &reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("flink jobmanager request to %s failed with status %d", url, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *FlinkJobManagerClient) CancelJobWithSavepoint(ctx context.Context, serviceName, jobId string) (string, error) {
+	url := fmt.Sprintf("%s/jobs/%s/savepoints", c.baseURL(serviceName), jobId)
+	var resp CancelJobResponse
+	if err := c.doJSON(ctx, http.MethodPost, url, CancelJobRequest{CancelJob: true}, &resp); err != nil {
+		return "", err
+	}
+	return resp.TriggerId, nil
+}
+
+func (c *FlinkJobManagerClient) SubmitJob(ctx context.Context, serviceName, jarName, savepointPath string, parallelism int32, allowNonRestoredState bool) (*SubmitJobResponse, error) {
+	url := fmt.Sprintf("%s/jars/%s/run", c.baseURL(serviceName), jarName)
+	req := SubmitJobRequest{
+		SavepointPath:         savepointPath,
+		Parallelism:           parallelism,
+		AllowNonRestoredState: allowNonRestoredState,
+	}
+	var resp SubmitJobResponse
+	if err := c.doJSON(ctx, http.MethodPost, url, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) CheckSavepointStatus(ctx context.Context, serviceName, jobId, triggerId string) (*SavepointResponse, error) {
+	url := fmt.Sprintf("%s/jobs/%s/savepoints/%s", c.baseURL(serviceName), jobId, triggerId)
+	var resp SavepointResponse
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) GetJobs(ctx context.Context, serviceName string) (*GetJobsResponse, error) {
+	url := fmt.Sprintf("%s/jobs", c.baseURL(serviceName))
+	var resp GetJobsResponse
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) GetJob(ctx context.Context, serviceName, jobId string) (*FlinkJob, error) {
+	url := fmt.Sprintf("%s/jobs/%s", c.baseURL(serviceName), jobId)
+	var resp FlinkJob
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) GetJobConfig(ctx context.Context, serviceName, jobId string) (*JobConfigResponse, error) {
+	url := fmt.Sprintf("%s/jobs/%s/config", c.baseURL(serviceName), jobId)
+	var resp JobConfigResponse
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) GetClusterOverview(ctx context.Context, serviceName string) (*ClusterOverviewResponse, error) {
+	url := fmt.Sprintf("%s/overview", c.baseURL(serviceName))
+	var resp ClusterOverviewResponse
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) GetLatestCheckpoint(ctx context.Context, serviceName, jobId string) (*CheckpointResponse, error) {
+	url := fmt.Sprintf("%s/jobs/%s/checkpoints", c.baseURL(serviceName), jobId)
+	var resp CheckpointResponse
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) UploadJar(ctx context.Context, serviceName, jarName string) (*JarUploadResponse, error) {
+	url := fmt.Sprintf("%s/jars/upload", c.baseURL(serviceName))
+	var resp JarUploadResponse
+	if err := c.doJSON(ctx, http.MethodPost, url, map[string]string{"filename": jarName}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *FlinkJobManagerClient) GetJarPlan(ctx context.Context, serviceName, jarName string, parallelism int32) (*JarPlanResponse, error) {
+	url := fmt.Sprintf("%s/jars/%s/plan?parallelism=%d", c.baseURL(serviceName), jarName, parallelism)
+	var resp JarPlanResponse
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -34,10 +34,11 @@ type CancelJobRequest struct {
 }
 
 type SubmitJobRequest struct {
-	SavepointPath string `json:"savepointPath"`
-	Parallelism   int32  `json:"parallelism"`
-	ProgramArgs   string `json:"programArgs"`
-	EntryClass    string `json:"entryClass"`
+	SavepointPath         string `json:"savepointPath"`
+	Parallelism           int32  `json:"parallelism"`
+	ProgramArgs           string `json:"programArgs"`
+	EntryClass            string `json:"entryClass"`
+	AllowNonRestoredState bool   `json:"allowNonRestoredState"`
 }
 
 type SavepointResponse struct {
@@ -118,3 +119,21 @@ type CheckpointResponse struct {
 	Latest  LatestCheckpoints      `json:"latest"`
 	History []CheckpointStatistics `json:"history"`
 }
+
+// Returned by the jar upload endpoint used to stage a jar for a dry-run plan request.
+type JarUploadResponse struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+}
+
+// Returned by the jar plan endpoint. A successful plan response means the JobManager was
+// able to build an execution graph for the jar/entry class/args/parallelism combination
+// without actually starting a job.
+type JarPlanResponse struct {
+	Plan JobPlan `json:"plan"`
+}
+
+type JobPlan struct {
+	JobId string `json:"jid"`
+	Name  string `json:"name"`
+}
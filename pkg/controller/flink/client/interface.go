@@ -0,0 +1,44 @@
+package client
+
+import "context"
+
+// FlinkAPIInterface wraps the JobManager REST API endpoints the operator needs. serviceName
+// identifies the JobManager's Kubernetes service and is resolved to a base URL by the
+// implementation.
+type FlinkAPIInterface interface {
+	// CancelJobWithSavepoint triggers a savepoint-then-cancel for jobId and returns the
+	// trigger id used to poll CheckSavepointStatus.
+	CancelJobWithSavepoint(ctx context.Context, serviceName, jobId string) (string, error)
+
+	// SubmitJob starts jarName as a new job. savepointPath, if non-empty, restores from that
+	// savepoint/checkpoint; allowNonRestoredState mirrors Flink's submission flag of the same
+	// name for schema-evolution deploys where the job graph no longer matches every operator in
+	// the restored state.
+	SubmitJob(ctx context.Context, serviceName, jarName, savepointPath string, parallelism int32, allowNonRestoredState bool) (*SubmitJobResponse, error)
+
+	// CheckSavepointStatus polls the status of a savepoint triggered by CancelJobWithSavepoint.
+	CheckSavepointStatus(ctx context.Context, serviceName, jobId, triggerId string) (*SavepointResponse, error)
+
+	// GetJobs lists all jobs known to the cluster.
+	GetJobs(ctx context.Context, serviceName string) (*GetJobsResponse, error)
+
+	// GetJob fetches the current status of a single job.
+	GetJob(ctx context.Context, serviceName, jobId string) (*FlinkJob, error)
+
+	// GetJobConfig fetches the execution config (including live parallelism) of a job.
+	GetJobConfig(ctx context.Context, serviceName, jobId string) (*JobConfigResponse, error)
+
+	// GetClusterOverview fetches cluster-wide stats, including free task slots.
+	GetClusterOverview(ctx context.Context, serviceName string) (*ClusterOverviewResponse, error)
+
+	// GetLatestCheckpoint fetches the checkpoint/savepoint history for a job.
+	GetLatestCheckpoint(ctx context.Context, serviceName, jobId string) (*CheckpointResponse, error)
+
+	// UploadJar stages jarName with the JobManager so a plan (and later a submit) can
+	// reference it.
+	UploadJar(ctx context.Context, serviceName, jarName string) (*JarUploadResponse, error)
+
+	// GetJarPlan dry-runs building an execution plan for jarName at the given parallelism,
+	// without starting a job.
+	GetJarPlan(ctx context.Context, serviceName, jarName string, parallelism int32) (*JarPlanResponse, error)
+}
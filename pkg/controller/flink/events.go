@@ -0,0 +1,72 @@
+package flink
+
+import (
+	"sync"
+)
+
+// EventType identifies the kind of change a FlinkJobStatusWatcher observed.
+type EventType string
+
+const (
+	// JobStatusChanged fires whenever a job's observed client.FlinkJobStatus differs from the
+	// last-seen value.
+	JobStatusChanged EventType = "JobStatusChanged"
+	// CheckpointFailed fires when the latest failed checkpoint reported by /jobs/:id/checkpoints
+	// has a different id from the last failed checkpoint already seen for the job. It never fires
+	// on a job's first poll, which only records the baseline.
+	CheckpointFailed EventType = "CheckpointFailed"
+	// TaskManagerLost fires when /overview reports fewer task managers than were present on the
+	// previous poll for a RUNNING job.
+	TaskManagerLost EventType = "TaskManagerLost"
+	// JobRestarted fires when a job that has already been seen RUNNING is seen RUNNING again
+	// after a poll that saw it in another state. A job's first transition to RUNNING (e.g. from
+	// CREATED) does not fire it.
+	JobRestarted EventType = "JobRestarted"
+)
+
+// JobEvent is emitted on the EventBus by the FlinkJobStatusWatcher.
+type JobEvent struct {
+	Type            EventType
+	Namespace       string
+	ApplicationName string
+	JobId           string
+	Message         string
+}
+
+// EventBus is a minimal in-process pub/sub used to decouple status discovery (the
+// FlinkJobStatusWatcher) from consumers such as the reconciler. Subscribers that are slow to
+// drain their channel do not block publishers; events are dropped for that subscriber instead.
+type EventBus interface {
+	Subscribe() <-chan JobEvent
+	Publish(event JobEvent)
+}
+
+// NewEventBus returns an EventBus backed by buffered channels, one per subscriber.
+func NewEventBus() EventBus {
+	return &eventBus{}
+}
+
+type eventBus struct {
+	mu          sync.RWMutex
+	subscribers []chan JobEvent
+}
+
+func (b *eventBus) Subscribe() <-chan JobEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan JobEvent, 100)
+	b.subscribers = append(b.subscribers, ch)
+	return ch
+}
+
+func (b *eventBus) Publish(event JobEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber isn't keeping up; drop rather than block the watcher.
+		}
+	}
+}
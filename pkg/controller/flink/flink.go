@@ -3,15 +3,24 @@ package flink
 import (
 	"context"
 
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
 	"github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
 	"github.com/lyft/flinkk8soperator/pkg/controller/k8"
 	"k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Annotation recording the time at which an old (no-longer-matching-spec) cluster deployment
+// becomes eligible for deletion, used to implement OldClusterRetentionSeconds.
+const oldClusterRetireAtAnnotation = "flink.k8s.io/retire-at"
+
 // Interface to manage Flink Application in Kubernetes
 type FlinkInterface interface {
 	// Creates a Flink cluster with necessary Job Manager, Task Managers and services for UI
@@ -55,6 +64,37 @@ type FlinkInterface interface {
 
 	// Returns the list of Jobs running on the Flink Cluster for the Application
 	GetJobsForApplication(ctx context.Context, application *v1alpha1.FlinkApplication) ([]client.FlinkJob, error)
+
+	// Dry-runs a submit of the given version against the JobManager (jar upload followed by a
+	// plan request) without starting a job. Used to gate promotion of a new version before it
+	// is ever made active.
+	ValidateApplicationVersion(ctx context.Context, application *v1alpha1.FlinkApplication, version v1alpha1.ApplicationVersion) error
+
+	// Rolls the Application back to a previously recorded version from its status history.
+	RollbackToVersion(ctx context.Context, application *v1alpha1.FlinkApplication, versionName string) (*v1alpha1.ApplicationVersion, error)
+
+	// HasSlotCapacity reports whether a session cluster has enough free slots to accept another
+	// job at the given parallelism. Only meaningful when Spec.DeploymentMode is Session.
+	HasSlotCapacity(ctx context.Context, application *v1alpha1.FlinkApplication, parallelism int32) (bool, error)
+
+	// StartSessionJob submits job's jar to the session cluster hosted by application and
+	// returns the resulting Flink job id. The caller is responsible for persisting the id onto
+	// job.Status.JobId; this never touches Status.ActiveJobId, since a session cluster hosts
+	// many jobs at once.
+	StartSessionJob(ctx context.Context, application *v1alpha1.FlinkApplication, job *v1alpha1.FlinkJob) (string, error)
+
+	// CancelSessionJobWithSavepoint cancels a single job running on a session cluster, keyed by
+	// job.Status.JobId rather than the cluster-wide Status.ActiveJobId used in Application mode.
+	CancelSessionJobWithSavepoint(ctx context.Context, application *v1alpha1.FlinkApplication, job *v1alpha1.FlinkJob) (string, error)
+
+	// IsSessionJobParallelismDifferent is the session-cluster counterpart of
+	// IsApplicationParallelismDifferent, comparing job.Spec.Parallelism against the live
+	// parallelism of job.Status.JobId.
+	IsSessionJobParallelismDifferent(ctx context.Context, application *v1alpha1.FlinkApplication, job *v1alpha1.FlinkJob) (bool, error)
+
+	// RestoreFromLatestCheckpoint returns the external path of the newest usable checkpoint or
+	// savepoint for application, for use as a restore path when none was explicitly configured.
+	RestoreFromLatestCheckpoint(ctx context.Context, application *v1alpha1.FlinkApplication) (string, error)
 }
 
 func NewFlinkController() FlinkInterface {
@@ -71,6 +111,10 @@ type FlinkController struct {
 	flinkJobManager  FlinkJobManagerControllerInterface
 	FlinkTaskManager FlinkTaskManagerControllerInterface
 	flinkClient      client.FlinkAPIInterface
+	// savepointStore prunes old savepoints per Spec.SavepointRetention after a successful
+	// restore. Left nil until a concrete backend (S3, GCS, HDFS, ...) is wired in; pruning is
+	// skipped when unset, same as an unset SavepointRetention skips it.
+	savepointStore SavepointStore
 }
 
 func GetActiveFlinkJob(jobs []client.FlinkJob) *client.FlinkJob {
@@ -86,13 +130,32 @@ func GetActiveFlinkJob(jobs []client.FlinkJob) *client.FlinkJob {
 	return nil
 }
 
+// isSessionMode reports whether application is hosted on a shared, multi-job session cluster
+// (Spec.DeploymentMode == v1alpha1.DeploymentModeSession) rather than owning a dedicated
+// single-job Application-mode cluster. It is the dispatch point the single-job methods below use
+// to refuse to guess at a job identity that, in session mode, only a specific FlinkJob carries.
+func (f *FlinkController) isSessionMode(application *v1alpha1.FlinkApplication) bool {
+	return application.Spec.DeploymentMode == v1alpha1.DeploymentModeSession
+}
+
+// IsApplicationParallelismDifferent compares the Application's spec parallelism against the
+// live parallelism of its single job. Only meaningful in Application mode: a session cluster has
+// no single parallelism to compare against, since each FlinkJob has its own; callers in that mode
+// must use IsSessionJobParallelismDifferent against the specific FlinkJob instead.
 func (f *FlinkController) IsApplicationParallelismDifferent(ctx context.Context, application *v1alpha1.FlinkApplication) (bool, error) {
+	if f.isSessionMode(application) {
+		return false, errors.New(fmt.Sprintf(
+			"application %s is running in session mode; use IsSessionJobParallelismDifferent with the specific FlinkJob", application.Name))
+	}
 	serviceName := getJobManagerServiceName(*application)
 	jobId, err := f.getJobIdForApplication(ctx, application)
 	if err != nil {
 		return false, err
 	}
 	jobConfig, err := f.flinkClient.GetJobConfig(ctx, serviceName, jobId)
+	if err != nil {
+		return false, err
+	}
 	if application.Spec.Parallelism != jobConfig.ExecutionConfig.Parallelism {
 		return true, nil
 	}
@@ -137,7 +200,14 @@ func (f *FlinkController) getDeploymentsForImage(ctx context.Context, applicatio
 	return f.k8Cluster.GetDeploymentsWithLabel(ctx, application.Namespace, imageLabels)
 }
 
+// GetJobsForApplication returns the jobs running for application. In Session mode this is keyed
+// off each owned FlinkJob's Status.JobId (queried via the k8 API, since the cluster hosts jobs
+// belonging to many FlinkJob CRs); in Application mode it asks the cluster directly, since the
+// cluster hosts exactly the one job.
 func (f *FlinkController) GetJobsForApplication(ctx context.Context, application *v1alpha1.FlinkApplication) ([]client.FlinkJob, error) {
+	if f.isSessionMode(application) {
+		return f.getSessionClusterJobs(ctx, application)
+	}
 	serviceName := getJobManagerServiceName(*application)
 	jobResponse, err := f.flinkClient.GetJobs(ctx, serviceName)
 	if err != nil {
@@ -146,9 +216,37 @@ func (f *FlinkController) GetJobsForApplication(ctx context.Context, application
 	return jobResponse.Jobs, nil
 }
 
-// The operator for now assumes and is intended to run single application per Flink Cluster.
-// Once we move to run multiple applications, this has to be removed/updated
+// getSessionClusterJobs lists every FlinkJob owned by application (a session cluster) and
+// reports each one's last-known job id and status from FlinkJob.Status, skipping jobs that have
+// not been submitted yet (empty Status.JobId).
+func (f *FlinkController) getSessionClusterJobs(ctx context.Context, application *v1alpha1.FlinkApplication) ([]client.FlinkJob, error) {
+	flinkJobs, err := f.k8Cluster.ListFlinkJobs(ctx, application.Namespace, k8.GetAppLabel(application.Name))
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]client.FlinkJob, 0, len(flinkJobs))
+	for _, flinkJob := range flinkJobs {
+		if flinkJob.Status.JobId == "" {
+			continue
+		}
+		jobs = append(jobs, client.FlinkJob{
+			JobId:  flinkJob.Status.JobId,
+			Status: client.FlinkJobStatus(flinkJob.Status.Status),
+		})
+	}
+	return jobs, nil
+}
+
+// getJobIdForApplication resolves the single active job id for an Application-mode cluster,
+// i.e. Spec.DeploymentMode == v1alpha1.DeploymentModeApplication (the default, and the only mode
+// supported before session clusters were introduced). Session-mode clusters host many jobs and
+// must be addressed via their own FlinkJob's Status.JobId instead; see CancelSessionJobWithSavepoint
+// and StartSessionJob.
 func (f *FlinkController) getJobIdForApplication(ctx context.Context, application *v1alpha1.FlinkApplication) (string, error) {
+	if f.isSessionMode(application) {
+		return "", errors.New(fmt.Sprintf(
+			"application %s is running in session mode; job id must be resolved per FlinkJob, not from the shared cluster", application.Name))
+	}
 	if application.Status.ActiveJobId != "" {
 		return application.Status.ActiveJobId, nil
 	}
@@ -164,7 +262,13 @@ func (f *FlinkController) getJobIdForApplication(ctx context.Context, applicatio
 	return activeJob.JobId, nil
 }
 
+// CancelWithSavepoint cancels the running job(s) for application. In Session mode this cancels
+// every job owned by the session cluster, each keyed by its own FlinkJob.Status.JobId, rather
+// than a single cluster-wide job id; in Application mode it cancels the one job as before.
 func (f *FlinkController) CancelWithSavepoint(ctx context.Context, application *v1alpha1.FlinkApplication) (string, error) {
+	if f.isSessionMode(application) {
+		return f.cancelAllSessionJobsWithSavepoint(ctx, application)
+	}
 	serviceName := getJobManagerServiceName(*application)
 	jobId, err := f.getJobIdForApplication(ctx, application)
 	if err != nil {
@@ -173,8 +277,45 @@ func (f *FlinkController) CancelWithSavepoint(ctx context.Context, application *
 	return f.flinkClient.CancelJobWithSavepoint(ctx, serviceName, jobId)
 }
 
+// cancelAllSessionJobsWithSavepoint cancels every submitted FlinkJob on application's session
+// cluster, keyed by each job's own Status.JobId. It returns the trigger id of the last job
+// cancelled; callers that need to poll every triggered savepoint individually should use
+// CancelSessionJobWithSavepoint per FlinkJob instead.
+func (f *FlinkController) cancelAllSessionJobsWithSavepoint(ctx context.Context, application *v1alpha1.FlinkApplication) (string, error) {
+	serviceName := getJobManagerServiceName(*application)
+	flinkJobs, err := f.k8Cluster.ListFlinkJobs(ctx, application.Namespace, k8.GetAppLabel(application.Name))
+	if err != nil {
+		return "", err
+	}
+	var lastTriggerId string
+	for _, flinkJob := range flinkJobs {
+		if flinkJob.Status.JobId == "" {
+			continue
+		}
+		triggerId, err := f.flinkClient.CancelJobWithSavepoint(ctx, serviceName, flinkJob.Status.JobId)
+		if err != nil {
+			return "", err
+		}
+		lastTriggerId = triggerId
+	}
+	return lastTriggerId, nil
+}
+
+// CreateCluster records the spec as a new ApplicationVersion (if it differs from the last one
+// recorded) before standing up the JobManager/TaskManager deployments and services, so that
+// every cluster this Application ever runs corresponds to exactly one entry in
+// Status.VersionHistory. If an earlier version of this Application is already running, the new
+// version is validated against it (see validateBeforePromotion) before its deployments are ever
+// created.
 func (f *FlinkController) CreateCluster(ctx context.Context, application *v1alpha1.FlinkApplication) error {
-	err := f.flinkJobManager.CreateIfNotExist(ctx, application)
+	version, err := f.RecordApplicationVersion(ctx, application)
+	if err != nil {
+		return err
+	}
+	if err := f.validateBeforePromotion(ctx, application, version); err != nil {
+		return err
+	}
+	err = f.flinkJobManager.CreateIfNotExist(ctx, application)
 	if err != nil {
 		return err
 	}
@@ -185,14 +326,101 @@ func (f *FlinkController) CreateCluster(ctx context.Context, application *v1alph
 	return nil
 }
 
+// RecordApplicationVersion computes the hash of application's current spec and, if it differs
+// from the most recently recorded version (or none has been recorded yet), appends a new,
+// monotonically-numbered ApplicationVersion to Status.VersionHistory and advances
+// Status.CurrentVersion to it. If the hash is unchanged, the existing current version is
+// returned unmodified. It only changes application.Status in memory; writing that status back to
+// the FlinkApplication object is left to the caller.
+func (f *FlinkController) RecordApplicationVersion(ctx context.Context, application *v1alpha1.FlinkApplication) (*v1alpha1.ApplicationVersion, error) {
+	hash, err := HashApplicationSpec(application)
+	if err != nil {
+		return nil, err
+	}
+
+	history := application.Status.VersionHistory
+	if len(history) > 0 && history[len(history)-1].SpecHash == hash {
+		return &history[len(history)-1], nil
+	}
+
+	versionNumber := int64(len(history) + 1)
+	version := v1alpha1.ApplicationVersion{
+		Name:              fmt.Sprintf("v%d", versionNumber),
+		VersionNumber:     versionNumber,
+		CreatedAt:         metav1.NewTime(time.Now()),
+		Image:             application.Spec.Image,
+		JobJarName:        application.JobJarName,
+		Parallelism:       application.Spec.Parallelism,
+		SpecHash:          hash,
+		SavepointLocation: application.SavepointInfo.SavepointLocation,
+	}
+	application.Status.VersionHistory = append(history, version)
+	application.Status.CurrentVersion = version.Name
+	return &version, nil
+}
+
+// validateBeforePromotion dry-runs version against the cluster currently running for application
+// (see ValidateApplicationVersion) before CreateCluster ever creates its deployments, so a bad
+// jar or an incompatible plan never displaces a healthy running job. If no cluster has been
+// created for this Application yet there is nothing running to validate against, so this is a
+// no-op; a version that has already been validated on a previous reconcile is not re-validated.
+func (f *FlinkController) validateBeforePromotion(ctx context.Context, application *v1alpha1.FlinkApplication, version *v1alpha1.ApplicationVersion) error {
+	if version.Validated {
+		return nil
+	}
+	existing, err := f.getDeploymentsForApp(ctx, application)
+	if err != nil {
+		return err
+	}
+	if len(existing.Items) == 0 {
+		return nil
+	}
+	if err := f.ValidateApplicationVersion(ctx, application, *version); err != nil {
+		return fmt.Errorf("version %s failed validation, leaving existing cluster in place: %w", version.Name, err)
+	}
+	for i := range application.Status.VersionHistory {
+		if application.Status.VersionHistory[i].Name == version.Name {
+			application.Status.VersionHistory[i].Validated = true
+		}
+	}
+	return nil
+}
+
+// StartFlinkJob submits the Application's jar to its JobManager. Only valid in Application mode,
+// since in Session mode a cluster hosts many jobs and there is no single jar to start; use
+// StartSessionJob for a specific FlinkJob there instead. If SavepointInfo.SavepointLocation is
+// empty (no explicit savepoint was requested) it falls back to RestoreFromLatestCheckpoint so
+// that a redeploy after a crash resumes from the most recent completed checkpoint rather than
+// starting from scratch.
 func (f *FlinkController) StartFlinkJob(ctx context.Context, application *v1alpha1.FlinkApplication) (string, error) {
+	if f.isSessionMode(application) {
+		return "", errors.New(fmt.Sprintf(
+			"application %s is running in session mode; submit individual jobs via StartSessionJob", application.Name))
+	}
+
 	serviceName := getJobManagerServiceName(*application)
+
+	restorePath := application.SavepointInfo.SavepointLocation
+	if restorePath == "" {
+		path, err := f.RestoreFromLatestCheckpoint(ctx, application)
+		if err != nil && err != ErrNoCheckpointToRestore {
+			return "", err
+		}
+		if err == nil {
+			if pruneErr := f.pruneSavepoints(ctx, application); pruneErr != nil {
+				return "", pruneErr
+			}
+		}
+		restorePath = path
+	}
+
 	response, err := f.flinkClient.SubmitJob(
 		ctx,
 		serviceName,
 		application.JobJarName,
-		application.SavepointInfo.SavepointLocation,
-		application.Spec.Parallelism)
+		restorePath,
+		application.Spec.Parallelism,
+		application.Spec.AllowNonRestoredState)
 	if err != nil {
 		return "", err
 	}
@@ -202,6 +430,65 @@ func (f *FlinkController) StartFlinkJob(ctx context.Context, application *v1alph
 	return response.JobId, nil
 }
 
+// ErrNoCheckpointToRestore is returned by RestoreFromLatestCheckpoint when the cluster's
+// checkpoint history contains no usable checkpoint or savepoint (e.g. the job never ran before).
+// Callers that treat "nothing to restore" as acceptable, such as StartFlinkJob, should check for
+// this specific error rather than ignoring every error RestoreFromLatestCheckpoint can return.
+var ErrNoCheckpointToRestore = errors.New("no usable checkpoint or savepoint found to restore from")
+
+// RestoreFromLatestCheckpoint queries the previous cluster's checkpoint history and returns the
+// external path of the newest COMPLETED, non-discarded checkpoint or savepoint, for use as the
+// restore path when a redeploy does not specify one explicitly. This is called from
+// StartFlinkJob, which runs against the *new* cluster after CreateCluster has already recorded
+// the new spec as the latest ApplicationVersion; the checkpoint history therefore has to be read
+// from the *previous* cluster, which is still addressed by the previous version's image (the
+// penultimate entry in Status.VersionHistory), not by application.Spec.Image.
+func (f *FlinkController) RestoreFromLatestCheckpoint(ctx context.Context, application *v1alpha1.FlinkApplication) (string, error) {
+	previousImage, ok := previousVersionImage(application)
+	if !ok {
+		return "", ErrNoCheckpointToRestore
+	}
+	previousCluster := *application
+	previousCluster.Spec.Image = previousImage
+	serviceName := getJobManagerServiceName(previousCluster)
+
+	jobId, err := f.getJobIdForApplication(ctx, application)
+	if err != nil {
+		return "", err
+	}
+	checkpoints, err := f.flinkClient.GetLatestCheckpoint(ctx, serviceName, jobId)
+	if err != nil {
+		return "", err
+	}
+
+	var best *client.CheckpointStatistics
+	for _, candidate := range []*client.CheckpointStatistics{checkpoints.Latest.Savepoint, checkpoints.Latest.Completed} {
+		if candidate == nil || candidate.Status != client.CheckpointCompleted {
+			continue
+		}
+		if candidate.ExternalPath == "" || candidate.Discarded {
+			continue
+		}
+		if best == nil || candidate.TriggerTimestamp > best.TriggerTimestamp {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return "", ErrNoCheckpointToRestore
+	}
+	return best.ExternalPath, nil
+}
+
+// pruneSavepoints enforces Spec.SavepointRetention via f.savepointStore, if one is configured. It
+// is a no-op when no backend has been wired in, same as PruneSavepoints is a no-op when
+// SavepointRetention is unset.
+func (f *FlinkController) pruneSavepoints(ctx context.Context, application *v1alpha1.FlinkApplication) error {
+	if f.savepointStore == nil {
+		return nil
+	}
+	return PruneSavepoints(ctx, f.savepointStore, application)
+}
+
 func (f *FlinkController) GetSavepointStatus(ctx context.Context, application *v1alpha1.FlinkApplication) (*client.SavepointResponse, error) {
 	serviceName := getJobManagerServiceName(*application)
 	jobId, err := f.getJobIdForApplication(ctx, application)
@@ -211,6 +498,11 @@ func (f *FlinkController) GetSavepointStatus(ctx context.Context, application *v
 	return f.flinkClient.CheckSavepointStatus(ctx, serviceName, jobId, application.SavepointInfo.TriggerId)
 }
 
+// DeleteOldCluster deletes deployments that no longer match the Application's spec. If
+// Spec.OldClusterRetentionSeconds is set, deletion is deferred: the first time a deployment is
+// seen as old it is annotated with the time it becomes eligible for deletion rather than deleted
+// immediately, giving operators a window to roll back a blue/green deploy before the previous
+// cluster is torn down.
 func (f *FlinkController) DeleteOldCluster(ctx context.Context, application *v1alpha1.FlinkApplication, deleteFrontEnd bool) error {
 	_, oldDeployments, err := f.getCurrentAndOldDeploymentsForApp(ctx, application)
 	if err != nil {
@@ -219,13 +511,40 @@ func (f *FlinkController) DeleteOldCluster(ctx context.Context, application *v1a
 	if len(oldDeployments) == 0 {
 		return nil
 	}
-	err = f.k8Cluster.DeleteDeployments(ctx, v1.DeploymentList{
-		Items: oldDeployments,
-	})
-	if err != nil {
-		return err
+
+	retentionSeconds := application.Spec.OldClusterRetentionSeconds
+	if retentionSeconds == 0 {
+		return f.k8Cluster.DeleteDeployments(ctx, v1.DeploymentList{
+			Items: oldDeployments,
+		})
+	}
+
+	deployments := make([]v1.Deployment, 0, len(oldDeployments))
+	for _, deployment := range oldDeployments {
+		retireAt, annotated := deployment.Annotations[oldClusterRetireAtAnnotation]
+		if !annotated {
+			if deployment.Annotations == nil {
+				deployment.Annotations = map[string]string{}
+			}
+			deployment.Annotations[oldClusterRetireAtAnnotation] =
+				time.Now().Add(time.Duration(retentionSeconds) * time.Second).Format(time.RFC3339)
+			if err := f.k8Cluster.UpdateK8Object(ctx, &deployment); err != nil {
+				return err
+			}
+			continue
+		}
+		retireTime, err := time.Parse(time.RFC3339, retireAt)
+		if err != nil || time.Now().Before(retireTime) {
+			continue
+		}
+		deployments = append(deployments, deployment)
 	}
-	return nil
+	if len(deployments) == 0 {
+		return nil
+	}
+	return f.k8Cluster.DeleteDeployments(ctx, v1.DeploymentList{
+		Items: deployments,
+	})
 }
 
 func (f *FlinkController) IsClusterReady(ctx context.Context, application *v1alpha1.FlinkApplication) (bool, error) {
@@ -294,3 +613,155 @@ func (f *FlinkController) isClusterUpdateNeeded(ctx context.Context, application
 	}
 	return f.IsApplicationParallelismDifferent(ctx, application)
 }
+
+// HashApplicationSpec computes a stable hash of the spec fields that define an ApplicationVersion
+// (image, jar, parallelism, args), so that two versions with the same hash are known to be
+// deploy-equivalent even if other metadata differs.
+func HashApplicationSpec(application *v1alpha1.FlinkApplication) (string, error) {
+	versioned := struct {
+		Image       string
+		JobJarName  string
+		Parallelism int32
+		Args        []string
+	}{
+		Image:       application.Spec.Image,
+		JobJarName:  application.JobJarName,
+		Parallelism: application.Spec.Parallelism,
+		Args:        application.Spec.Args,
+	}
+	b, err := json.Marshal(versioned)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// previousVersionImage returns the image of the version recorded immediately before the current
+// one in Status.VersionHistory, i.e. the cluster a redeploy is transitioning away from. Returns
+// false if there's no such version (the application has never been deployed before, or
+// RecordApplicationVersion has not yet recorded the redeploy that's in progress).
+func previousVersionImage(application *v1alpha1.FlinkApplication) (string, bool) {
+	history := application.Status.VersionHistory
+	if len(history) < 2 {
+		return "", false
+	}
+	return history[len(history)-2].Image, true
+}
+
+// ValidateApplicationVersion dry-runs a submit of the given version against the JobManager: it
+// uploads the version's jar and requests an execution plan for it at the version's parallelism,
+// without ever starting a job. A successful plan response means the JobManager could build an
+// execution plan for that jar and parallelism; the plan request carries no entry class or args,
+// so those are not checked.
+func (f *FlinkController) ValidateApplicationVersion(ctx context.Context, application *v1alpha1.FlinkApplication, version v1alpha1.ApplicationVersion) error {
+	serviceName := getJobManagerServiceName(*application)
+	uploadResp, err := f.flinkClient.UploadJar(ctx, serviceName, version.JobJarName)
+	if err != nil {
+		return err
+	}
+	if uploadResp.Status != "success" {
+		return errors.New(fmt.Sprintf("failed to upload jar for version %s: %s", version.Name, uploadResp.Status))
+	}
+	_, err = f.flinkClient.GetJarPlan(ctx, serviceName, version.JobJarName, version.Parallelism)
+	if err != nil {
+		return errors.New(fmt.Sprintf("version %s failed plan validation: %v", version.Name, err))
+	}
+	return nil
+}
+
+// RollbackToVersion looks up versionName in the Application's recorded version history and
+// returns it so the caller can re-deploy the cluster using that version's image, jar and
+// savepoint reference. It does not itself mutate the cluster; the reconciler is responsible for
+// driving the Application back to the returned version the same way it drives any other update.
+func (f *FlinkController) RollbackToVersion(ctx context.Context, application *v1alpha1.FlinkApplication, versionName string) (*v1alpha1.ApplicationVersion, error) {
+	for _, version := range application.Status.VersionHistory {
+		if version.Name == versionName {
+			return &version, nil
+		}
+	}
+	return nil, errors.New(fmt.Sprintf("no recorded version named %s for application %s", versionName, application.Name))
+}
+
+// HasSlotCapacity reports whether the session cluster backing application has enough free slots
+// (per ClusterOverviewResponse.SlotsAvailable) to accept a new job at the given parallelism.
+func (f *FlinkController) HasSlotCapacity(ctx context.Context, application *v1alpha1.FlinkApplication, parallelism int32) (bool, error) {
+	serviceName := getJobManagerServiceName(*application)
+	overview, err := f.flinkClient.GetClusterOverview(ctx, serviceName)
+	if err != nil {
+		return false, err
+	}
+	return int32(overview.SlotsAvailable) >= parallelism, nil
+}
+
+// StartSessionJob submits job's jar to the session cluster hosted by application, without
+// touching application.Status.ActiveJobId. The caller persists the returned job id onto
+// job.Status.JobId.
+func (f *FlinkController) StartSessionJob(ctx context.Context, application *v1alpha1.FlinkApplication, job *v1alpha1.FlinkJob) (string, error) {
+	hasCapacity, err := f.HasSlotCapacity(ctx, application, job.Spec.Parallelism)
+	if err != nil {
+		return "", err
+	}
+	if !hasCapacity {
+		return "", errors.New(fmt.Sprintf("session cluster %s has no free slots for job %s", application.Name, job.Name))
+	}
+
+	serviceName := getJobManagerServiceName(*application)
+	response, err := f.flinkClient.SubmitJob(
+		ctx,
+		serviceName,
+		job.Spec.JobJarName,
+		job.Spec.SavepointInfo.SavepointLocation,
+		job.Spec.Parallelism,
+		job.Spec.AllowNonRestoredState)
+	if err != nil {
+		return "", err
+	}
+	if response.JobId == "" {
+		return "", errors.New("unable to submit session job: invalid job id")
+	}
+	return response.JobId, nil
+}
+
+// CancelSessionJobWithSavepoint cancels a single job on a session cluster, keyed by
+// job.Status.JobId rather than the cluster-wide Status.ActiveJobId used in Application mode.
+func (f *FlinkController) CancelSessionJobWithSavepoint(ctx context.Context, application *v1alpha1.FlinkApplication, job *v1alpha1.FlinkJob) (string, error) {
+	if job.Status.JobId == "" {
+		return "", errors.New(fmt.Sprintf("job %s has no JobId recorded in status", job.Name))
+	}
+	serviceName := getJobManagerServiceName(*application)
+	return f.flinkClient.CancelJobWithSavepoint(ctx, serviceName, job.Status.JobId)
+}
+
+// IsSessionJobParallelismDifferent compares job.Spec.Parallelism against the live parallelism of
+// job.Status.JobId, the session-cluster counterpart of IsApplicationParallelismDifferent.
+func (f *FlinkController) IsSessionJobParallelismDifferent(ctx context.Context, application *v1alpha1.FlinkApplication, job *v1alpha1.FlinkJob) (bool, error) {
+	if job.Status.JobId == "" {
+		return false, errors.New(fmt.Sprintf("job %s has no JobId recorded in status", job.Name))
+	}
+	serviceName := getJobManagerServiceName(*application)
+	jobConfig, err := f.flinkClient.GetJobConfig(ctx, serviceName, job.Status.JobId)
+	if err != nil {
+		return false, err
+	}
+	return job.Spec.Parallelism != jobConfig.ExecutionConfig.Parallelism, nil
+}
+
+// DiffApplicationVersions reports the spec fields that differ between two recorded versions, for
+// display in status/events when a rollback or promotion is being considered.
+func DiffApplicationVersions(from, to v1alpha1.ApplicationVersion) map[string]string {
+	diff := map[string]string{}
+	if from.Image != to.Image {
+		diff["image"] = fmt.Sprintf("%s -> %s", from.Image, to.Image)
+	}
+	if from.JobJarName != to.JobJarName {
+		diff["jobJarName"] = fmt.Sprintf("%s -> %s", from.JobJarName, to.JobJarName)
+	}
+	if from.Parallelism != to.Parallelism {
+		diff["parallelism"] = fmt.Sprintf("%d -> %d", from.Parallelism, to.Parallelism)
+	}
+	if from.SpecHash != to.SpecHash {
+		diff["specHash"] = fmt.Sprintf("%s -> %s", from.SpecHash, to.SpecHash)
+	}
+	return diff
+}
@@ -0,0 +1,165 @@
+package flink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+)
+
+func TestHashApplicationSpec_SameSpecSameHash(t *testing.T) {
+	app := &v1alpha1.FlinkApplication{
+		Spec:       v1alpha1.FlinkApplicationSpec{Image: "flink:1", Parallelism: 4, Args: []string{"--foo"}},
+		JobJarName: "job.jar",
+	}
+	h1, err := HashApplicationSpec(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h2, err := HashApplicationSpec(app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h1 != h2 {
+		t.Errorf("expected the same spec to hash identically, got %q and %q", h1, h2)
+	}
+}
+
+func TestHashApplicationSpec_DifferentImageDifferentHash(t *testing.T) {
+	app1 := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{Image: "flink:1"}}
+	app2 := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{Image: "flink:2"}}
+	h1, _ := HashApplicationSpec(app1)
+	h2, _ := HashApplicationSpec(app2)
+	if h1 == h2 {
+		t.Errorf("expected different images to produce different hashes")
+	}
+}
+
+func TestRecordApplicationVersion_FirstCallAppendsVersion(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{Image: "flink:1"}}
+
+	version, err := f.RecordApplicationVersion(context.Background(), app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Name != "v1" || version.VersionNumber != 1 {
+		t.Errorf("expected the first recorded version to be v1, got %+v", version)
+	}
+	if len(app.Status.VersionHistory) != 1 {
+		t.Fatalf("expected VersionHistory to have one entry, got %d", len(app.Status.VersionHistory))
+	}
+	if app.Status.CurrentVersion != "v1" {
+		t.Errorf("expected CurrentVersion to be v1, got %q", app.Status.CurrentVersion)
+	}
+}
+
+func TestRecordApplicationVersion_UnchangedSpecDoesNotDuplicate(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{Image: "flink:1"}}
+
+	if _, err := f.RecordApplicationVersion(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.RecordApplicationVersion(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(app.Status.VersionHistory) != 1 {
+		t.Errorf("expected re-recording an unchanged spec not to append a new version, got %d entries", len(app.Status.VersionHistory))
+	}
+}
+
+func TestRecordApplicationVersion_ChangedSpecAppendsNewVersion(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{Image: "flink:1"}}
+
+	if _, err := f.RecordApplicationVersion(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	app.Spec.Image = "flink:2"
+	version, err := f.RecordApplicationVersion(context.Background(), app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Name != "v2" || version.VersionNumber != 2 {
+		t.Errorf("expected the second distinct spec to be recorded as v2, got %+v", version)
+	}
+	if len(app.Status.VersionHistory) != 2 {
+		t.Errorf("expected VersionHistory to have two entries, got %d", len(app.Status.VersionHistory))
+	}
+}
+
+func TestPreviousVersionImage_NoPriorVersion(t *testing.T) {
+	app := &v1alpha1.FlinkApplication{}
+	if _, ok := previousVersionImage(app); ok {
+		t.Errorf("expected no previous version image when VersionHistory has fewer than two entries")
+	}
+
+	app.Status.VersionHistory = []v1alpha1.ApplicationVersion{{Name: "v1", Image: "flink:1"}}
+	if _, ok := previousVersionImage(app); ok {
+		t.Errorf("expected no previous version image when only the current version has been recorded")
+	}
+}
+
+func TestPreviousVersionImage_ReturnsPenultimateVersion(t *testing.T) {
+	app := &v1alpha1.FlinkApplication{}
+	app.Status.VersionHistory = []v1alpha1.ApplicationVersion{
+		{Name: "v1", Image: "flink:1"},
+		{Name: "v2", Image: "flink:2"},
+	}
+	image, ok := previousVersionImage(app)
+	if !ok {
+		t.Fatalf("expected a previous version image to be found")
+	}
+	if image != "flink:1" {
+		t.Errorf("expected the previous cluster's image to be flink:1 (the version before the latest), got %q", image)
+	}
+}
+
+func TestStartFlinkJob_RejectsSessionMode(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{DeploymentMode: v1alpha1.DeploymentModeSession}}
+	if _, err := f.StartFlinkJob(context.Background(), app); err == nil {
+		t.Errorf("expected StartFlinkJob to reject a session-mode application")
+	}
+}
+
+func TestIsApplicationParallelismDifferent_RejectsSessionMode(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{DeploymentMode: v1alpha1.DeploymentModeSession}}
+	if _, err := f.IsApplicationParallelismDifferent(context.Background(), app); err == nil {
+		t.Errorf("expected IsApplicationParallelismDifferent to reject a session-mode application")
+	}
+}
+
+func TestGetJobsForApplication_SessionModeDispatchesToFlinkJobs(t *testing.T) {
+	k8Cluster := &fakeSessionK8Cluster{
+		jobs: []v1alpha1.FlinkJob{
+			{Status: v1alpha1.FlinkJobStatus{JobId: "job-1", Status: "RUNNING"}},
+			{Status: v1alpha1.FlinkJobStatus{JobId: ""}}, // not yet submitted, should be skipped
+		},
+	}
+	f := &FlinkController{k8Cluster: k8Cluster}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{DeploymentMode: v1alpha1.DeploymentModeSession}}
+	app.Name = "my-app"
+	app.Namespace = "default"
+
+	jobs, err := f.GetJobsForApplication(context.Background(), app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].JobId != "job-1" {
+		t.Errorf("expected exactly the one submitted FlinkJob to be returned, got %+v", jobs)
+	}
+}
+
+// fakeSessionK8Cluster implements k8.K8ClusterInterface, returning a fixed list of FlinkJobs from
+// ListFlinkJobs; every other method panics since the session-dispatch tests above don't need them.
+type fakeSessionK8Cluster struct {
+	fakeK8Cluster
+	jobs []v1alpha1.FlinkJob
+}
+
+func (f *fakeSessionK8Cluster) ListFlinkJobs(ctx context.Context, namespace string, labels map[string]string) ([]v1alpha1.FlinkJob, error) {
+	return f.jobs, nil
+}
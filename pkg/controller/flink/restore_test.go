@@ -0,0 +1,239 @@
+package flink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+	flinkclient "github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+)
+
+// fakeK8ClusterWithDeployments reports `deployments` existing Deployments for
+// GetDeploymentsWithLabel and panics on every other method, for tests that only need to control
+// whether a cluster already exists for an Application.
+type fakeK8ClusterWithDeployments struct {
+	fakeK8Cluster
+	deployments int
+}
+
+func (f *fakeK8ClusterWithDeployments) GetDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) (*appsv1.DeploymentList, error) {
+	list := &appsv1.DeploymentList{}
+	for i := 0; i < f.deployments; i++ {
+		list.Items = append(list.Items, appsv1.Deployment{})
+	}
+	return list, nil
+}
+
+func appWithPriorVersion() *v1alpha1.FlinkApplication {
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{Image: "flink:2"}}
+	app.Status.ActiveJobId = "job-1"
+	app.Status.VersionHistory = []v1alpha1.ApplicationVersion{
+		{Name: "v1", Image: "flink:1"},
+		{Name: "v2", Image: "flink:2"},
+	}
+	return app
+}
+
+func TestRestoreFromLatestCheckpoint_NoPriorVersionReturnsErrNoCheckpointToRestore(t *testing.T) {
+	f := &FlinkController{flinkClient: &fakeFlinkAPI{}}
+	app := &v1alpha1.FlinkApplication{}
+
+	if _, err := f.RestoreFromLatestCheckpoint(context.Background(), app); err != ErrNoCheckpointToRestore {
+		t.Errorf("expected ErrNoCheckpointToRestore, got %v", err)
+	}
+}
+
+func TestRestoreFromLatestCheckpoint_NoUsableCheckpointReturnsErrNoCheckpointToRestore(t *testing.T) {
+	api := &fakeFlinkAPI{checkpoints: &flinkclient.CheckpointResponse{}}
+	f := &FlinkController{flinkClient: api}
+	app := appWithPriorVersion()
+
+	if _, err := f.RestoreFromLatestCheckpoint(context.Background(), app); err != ErrNoCheckpointToRestore {
+		t.Errorf("expected ErrNoCheckpointToRestore when the checkpoint history has no completed entries, got %v", err)
+	}
+}
+
+func TestRestoreFromLatestCheckpoint_ReturnsNewestCompletedEntry(t *testing.T) {
+	api := &fakeFlinkAPI{checkpoints: &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{
+		Completed: &flinkclient.CheckpointStatistics{
+			Status: flinkclient.CheckpointCompleted, ExternalPath: "s3://checkpoint-1", TriggerTimestamp: 100,
+		},
+		Savepoint: &flinkclient.CheckpointStatistics{
+			Status: flinkclient.CheckpointCompleted, ExternalPath: "s3://savepoint-1", TriggerTimestamp: 200,
+		},
+	}}}
+	f := &FlinkController{flinkClient: api}
+	app := appWithPriorVersion()
+
+	path, err := f.RestoreFromLatestCheckpoint(context.Background(), app)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "s3://savepoint-1" {
+		t.Errorf("expected the newer of the two completed entries (by TriggerTimestamp) to win, got %q", path)
+	}
+}
+
+func TestRestoreFromLatestCheckpoint_SkipsDiscardedCheckpoint(t *testing.T) {
+	api := &fakeFlinkAPI{checkpoints: &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{
+		Completed: &flinkclient.CheckpointStatistics{
+			Status: flinkclient.CheckpointCompleted, ExternalPath: "s3://checkpoint-1", Discarded: true,
+		},
+	}}}
+	f := &FlinkController{flinkClient: api}
+	app := appWithPriorVersion()
+
+	if _, err := f.RestoreFromLatestCheckpoint(context.Background(), app); err != ErrNoCheckpointToRestore {
+		t.Errorf("expected a discarded checkpoint to be ignored, got %v", err)
+	}
+}
+
+func TestValidateApplicationVersion_PlanFailureIsReported(t *testing.T) {
+	api := &fakeFlinkAPI{
+		uploadResp: &flinkclient.JarUploadResponse{Status: "success"},
+		jarPlanErr: context.DeadlineExceeded,
+	}
+	f := &FlinkController{flinkClient: api}
+	app := &v1alpha1.FlinkApplication{}
+	version := v1alpha1.ApplicationVersion{Name: "v2", JobJarName: "job.jar"}
+
+	if err := f.ValidateApplicationVersion(context.Background(), app, version); err == nil {
+		t.Errorf("expected a GetJarPlan error to fail validation")
+	}
+}
+
+func TestValidateApplicationVersion_UploadFailureStatusIsReported(t *testing.T) {
+	api := &fakeFlinkAPI{uploadResp: &flinkclient.JarUploadResponse{Status: "error"}}
+	f := &FlinkController{flinkClient: api}
+	app := &v1alpha1.FlinkApplication{}
+	version := v1alpha1.ApplicationVersion{Name: "v2", JobJarName: "job.jar"}
+
+	if err := f.ValidateApplicationVersion(context.Background(), app, version); err == nil {
+		t.Errorf("expected a non-success upload status to fail validation")
+	}
+}
+
+func TestValidateApplicationVersion_SuccessfulUploadAndPlan(t *testing.T) {
+	api := &fakeFlinkAPI{
+		uploadResp:  &flinkclient.JarUploadResponse{Status: "success"},
+		jarPlanResp: &flinkclient.JarPlanResponse{Plan: flinkclient.JobPlan{JobId: "plan-1"}},
+	}
+	f := &FlinkController{flinkClient: api}
+	app := &v1alpha1.FlinkApplication{}
+	version := v1alpha1.ApplicationVersion{Name: "v2", JobJarName: "job.jar"}
+
+	if err := f.ValidateApplicationVersion(context.Background(), app, version); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRollbackToVersion_ReturnsNamedVersion(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{}
+	app.Status.VersionHistory = []v1alpha1.ApplicationVersion{
+		{Name: "v1", Image: "flink:1"},
+		{Name: "v2", Image: "flink:2"},
+	}
+
+	version, err := f.RollbackToVersion(context.Background(), app, "v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Image != "flink:1" {
+		t.Errorf("expected the v1 entry to be returned, got %+v", version)
+	}
+}
+
+func TestRollbackToVersion_UnknownVersionIsAnError(t *testing.T) {
+	f := &FlinkController{}
+	app := &v1alpha1.FlinkApplication{}
+	app.Status.VersionHistory = []v1alpha1.ApplicationVersion{{Name: "v1", Image: "flink:1"}}
+
+	if _, err := f.RollbackToVersion(context.Background(), app, "v99"); err == nil {
+		t.Errorf("expected an error for a version name not present in VersionHistory")
+	}
+}
+
+func TestValidateBeforePromotion_NoExistingClusterSkipsValidation(t *testing.T) {
+	api := &fakeFlinkAPI{uploadErr: context.DeadlineExceeded}
+	f := &FlinkController{flinkClient: api, k8Cluster: &fakeK8ClusterWithDeployments{}}
+	app := &v1alpha1.FlinkApplication{}
+	version := &v1alpha1.ApplicationVersion{Name: "v1", JobJarName: "job.jar"}
+
+	if err := f.validateBeforePromotion(context.Background(), app, version); err != nil {
+		t.Errorf("expected validation to be skipped when no cluster is running yet, got %v", err)
+	}
+	if version.Validated {
+		t.Errorf("expected Validated to stay false when validation was skipped")
+	}
+}
+
+func TestValidateBeforePromotion_FailedValidationLeavesVersionUnvalidated(t *testing.T) {
+	api := &fakeFlinkAPI{uploadErr: context.DeadlineExceeded}
+	f := &FlinkController{flinkClient: api, k8Cluster: &fakeK8ClusterWithDeployments{deployments: 1}}
+	app := &v1alpha1.FlinkApplication{}
+	version := &v1alpha1.ApplicationVersion{Name: "v2", JobJarName: "job.jar"}
+
+	if err := f.validateBeforePromotion(context.Background(), app, version); err == nil {
+		t.Errorf("expected a failed dry-run to be reported")
+	}
+	if version.Validated {
+		t.Errorf("expected Validated to stay false after a failed dry-run")
+	}
+}
+
+func TestValidateBeforePromotion_SuccessfulValidationMarksVersionValidated(t *testing.T) {
+	api := &fakeFlinkAPI{
+		uploadResp:  &flinkclient.JarUploadResponse{Status: "success"},
+		jarPlanResp: &flinkclient.JarPlanResponse{Plan: flinkclient.JobPlan{JobId: "plan-1"}},
+	}
+	f := &FlinkController{flinkClient: api, k8Cluster: &fakeK8ClusterWithDeployments{deployments: 1}}
+	app := &v1alpha1.FlinkApplication{}
+	app.Status.VersionHistory = []v1alpha1.ApplicationVersion{{Name: "v2", JobJarName: "job.jar"}}
+	version := &app.Status.VersionHistory[0]
+
+	if err := f.validateBeforePromotion(context.Background(), app, version); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !app.Status.VersionHistory[0].Validated {
+		t.Errorf("expected the matching VersionHistory entry to be marked Validated")
+	}
+}
+
+func TestValidateBeforePromotion_AlreadyValidatedVersionIsNotReValidated(t *testing.T) {
+	api := &fakeFlinkAPI{uploadErr: context.DeadlineExceeded}
+	f := &FlinkController{flinkClient: api, k8Cluster: &fakeK8ClusterWithDeployments{deployments: 1}}
+	app := &v1alpha1.FlinkApplication{}
+	version := &v1alpha1.ApplicationVersion{Name: "v2", JobJarName: "job.jar", Validated: true}
+
+	if err := f.validateBeforePromotion(context.Background(), app, version); err != nil {
+		t.Errorf("expected an already-validated version not to be dry-run again, got %v", err)
+	}
+}
+
+func TestStartFlinkJob_PrunesSavepointsAfterSuccessfulRestore(t *testing.T) {
+	now := time.Now()
+	store := &fakeSavepointStore{savepoints: []SavepointMeta{
+		{Path: "sp-old", CreatedAt: now.Add(-time.Hour)},
+		{Path: "sp-new", CreatedAt: now},
+	}}
+	api := &fakeFlinkAPI{
+		checkpoints: &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{
+			Completed: &flinkclient.CheckpointStatistics{Status: flinkclient.CheckpointCompleted, ExternalPath: "s3://checkpoint-1"},
+		}},
+		submitResp: &flinkclient.SubmitJobResponse{JobId: "job-2"},
+	}
+	f := &FlinkController{flinkClient: api, savepointStore: store}
+	app := appWithPriorVersion()
+	app.Spec.SavepointRetention = v1alpha1.SavepointRetentionPolicy{Count: 1}
+
+	if _, err := f.StartFlinkJob(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "sp-old" {
+		t.Errorf("expected StartFlinkJob to prune down to Count=1 after a successful restore, got %v", store.deleted)
+	}
+}
@@ -0,0 +1,58 @@
+package flink
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+)
+
+// SavepointMeta describes one savepoint held in a SavepointStore's backend.
+type SavepointMeta struct {
+	Path      string
+	CreatedAt time.Time
+}
+
+// SavepointStore is the pluggable backend used to enforce Spec.SavepointRetention. Concrete
+// implementations (S3, GCS, HDFS) adapt their respective object-store clients to this interface.
+type SavepointStore interface {
+	// List returns every savepoint currently held for application, in the backend's configured
+	// path for that application.
+	List(ctx context.Context, application *v1alpha1.FlinkApplication) ([]SavepointMeta, error)
+
+	// Delete removes the savepoint at path.
+	Delete(ctx context.Context, application *v1alpha1.FlinkApplication, path string) error
+}
+
+// PruneSavepoints enforces application.Spec.SavepointRetention against store: it keeps at most
+// Count savepoints (newest first) and removes any older than MaxAgeSeconds. It is intended to be
+// called after a successful RestoreFromLatestCheckpoint, so that pruning only ever happens once
+// a newer savepoint is known to be restorable.
+func PruneSavepoints(ctx context.Context, store SavepointStore, application *v1alpha1.FlinkApplication) error {
+	retention := application.Spec.SavepointRetention
+	if retention.Count == 0 && retention.MaxAgeSeconds == 0 {
+		return nil
+	}
+
+	savepoints, err := store.List(ctx, application)
+	if err != nil {
+		return err
+	}
+	sort.Slice(savepoints, func(i, j int) bool {
+		return savepoints[i].CreatedAt.After(savepoints[j].CreatedAt)
+	})
+
+	now := time.Now()
+	for i, savepoint := range savepoints {
+		expiredByCount := retention.Count > 0 && i >= int(retention.Count)
+		expiredByAge := retention.MaxAgeSeconds > 0 && now.Sub(savepoint.CreatedAt) > time.Duration(retention.MaxAgeSeconds)*time.Second
+		if !expiredByCount && !expiredByAge {
+			continue
+		}
+		if err := store.Delete(ctx, application, savepoint.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
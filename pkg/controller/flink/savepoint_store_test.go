@@ -0,0 +1,88 @@
+package flink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+)
+
+// fakeSavepointStore implements SavepointStore over an in-memory slice, recording the paths
+// Delete is called with.
+type fakeSavepointStore struct {
+	savepoints []SavepointMeta
+	listErr    error
+	deleted    []string
+}
+
+func (f *fakeSavepointStore) List(ctx context.Context, application *v1alpha1.FlinkApplication) ([]SavepointMeta, error) {
+	return f.savepoints, f.listErr
+}
+
+func (f *fakeSavepointStore) Delete(ctx context.Context, application *v1alpha1.FlinkApplication, path string) error {
+	f.deleted = append(f.deleted, path)
+	return nil
+}
+
+func TestPruneSavepoints_NoRetentionConfiguredIsNoOp(t *testing.T) {
+	store := &fakeSavepointStore{savepoints: []SavepointMeta{{Path: "sp-1", CreatedAt: time.Now()}}}
+	app := &v1alpha1.FlinkApplication{}
+
+	if err := PruneSavepoints(context.Background(), store, app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 0 {
+		t.Errorf("expected no deletions when SavepointRetention is unset, got %v", store.deleted)
+	}
+}
+
+func TestPruneSavepoints_KeepsOnlyMostRecentCount(t *testing.T) {
+	now := time.Now()
+	store := &fakeSavepointStore{savepoints: []SavepointMeta{
+		{Path: "sp-oldest", CreatedAt: now.Add(-3 * time.Hour)},
+		{Path: "sp-newest", CreatedAt: now},
+		{Path: "sp-middle", CreatedAt: now.Add(-1 * time.Hour)},
+	}}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{
+		SavepointRetention: v1alpha1.SavepointRetentionPolicy{Count: 2},
+	}}
+
+	if err := PruneSavepoints(context.Background(), store, app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "sp-oldest" {
+		t.Errorf("expected only sp-oldest to be pruned, got %v", store.deleted)
+	}
+}
+
+func TestPruneSavepoints_DeletesSavepointsOlderThanMaxAge(t *testing.T) {
+	now := time.Now()
+	store := &fakeSavepointStore{savepoints: []SavepointMeta{
+		{Path: "sp-fresh", CreatedAt: now},
+		{Path: "sp-expired", CreatedAt: now.Add(-2 * time.Hour)},
+	}}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{
+		SavepointRetention: v1alpha1.SavepointRetentionPolicy{MaxAgeSeconds: 3600},
+	}}
+
+	if err := PruneSavepoints(context.Background(), store, app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "sp-expired" {
+		t.Errorf("expected only sp-expired to be pruned, got %v", store.deleted)
+	}
+}
+
+func TestPruneSavepoints_PropagatesListError(t *testing.T) {
+	listErr := errors.New("list failed")
+	store := &fakeSavepointStore{listErr: listErr}
+	app := &v1alpha1.FlinkApplication{Spec: v1alpha1.FlinkApplicationSpec{
+		SavepointRetention: v1alpha1.SavepointRetentionPolicy{Count: 1},
+	}}
+
+	if err := PruneSavepoints(context.Background(), store, app); err != listErr {
+		t.Errorf("expected the store's List error to be propagated, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package flink
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+	"github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+	"github.com/lyft/flinkk8soperator/pkg/controller/k8"
+)
+
+// Log lines that indicate a JobManager has reached a terminal state, used as a fallback when the
+// REST API is unreachable. Mirrors the markers Kubernetes-native Flink watchers grep for.
+var terminalLogMarkers = []string{
+	"Application completed",
+	"Job has been finished",
+}
+
+type jobState struct {
+	status           client.FlinkJobStatus
+	taskManagerCount uint
+	lastCheckpointId uint
+	// everRunning is set once job.Status is observed as FlinkJobRunning, and never cleared. It
+	// distinguishes a job's normal first CREATED -> RUNNING transition from an actual restart
+	// (RUNNING -> not-RUNNING -> RUNNING again), since the latter is the only case JobRestarted
+	// should fire for.
+	everRunning bool
+}
+
+// FlinkJobStatusWatcherInterface continuously polls the JobManager for each watched job on an
+// independent interval and emits typed JobEvents on an EventBus, decoupling status discovery from
+// the on-demand calls the reconcile loop makes via GetJobsForApplication. A Session-mode
+// application hosts many jobs at once, so watcher state is keyed by (application, jobId) rather
+// than by application alone: every call below takes jobId to identify which job's watch it acts on.
+type FlinkJobStatusWatcherInterface interface {
+	// Watch begins polling the given job at pollInterval and blocks until ctx is cancelled or
+	// StopWatching is called for the same (application, jobId).
+	Watch(ctx context.Context, application *v1alpha1.FlinkApplication, jobId string, pollInterval time.Duration)
+
+	// StopWatching cancels polling for the given (namespace, name, jobId).
+	StopWatching(namespace, name, jobId string)
+}
+
+func NewFlinkJobStatusWatcher(flinkClient client.FlinkAPIInterface, k8Cluster k8.K8ClusterInterface, bus EventBus) FlinkJobStatusWatcherInterface {
+	return &FlinkJobStatusWatcher{
+		flinkClient: flinkClient,
+		k8Cluster:   k8Cluster,
+		bus:         bus,
+		state:       map[string]jobState{},
+		stopChans:   map[string]chan struct{}{},
+	}
+}
+
+type FlinkJobStatusWatcher struct {
+	flinkClient client.FlinkAPIInterface
+	k8Cluster   k8.K8ClusterInterface
+	bus         EventBus
+
+	mu        sync.Mutex
+	state     map[string]jobState
+	stopChans map[string]chan struct{}
+}
+
+// watchKey identifies one watched job. Application mode has exactly one job per application, but
+// Session mode hosts many jobs on a shared cluster, so keying by (namespace, name) alone would let
+// a second job's Watch silently overwrite the first's stopChans entry, leaking its goroutine with
+// no way left to stop it; jobId makes each job's watcher state and stop channel independent.
+func watchKey(namespace, name, jobId string) string {
+	return namespace + "/" + name + "/" + jobId
+}
+
+func (w *FlinkJobStatusWatcher) Watch(ctx context.Context, application *v1alpha1.FlinkApplication, jobId string, pollInterval time.Duration) {
+	key := watchKey(application.Namespace, application.Name, jobId)
+	stop := make(chan struct{})
+	w.mu.Lock()
+	w.stopChans[key] = stop
+	w.mu.Unlock()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.poll(ctx, application, jobId)
+		}
+	}
+}
+
+func (w *FlinkJobStatusWatcher) StopWatching(namespace, name, jobId string) {
+	key := watchKey(namespace, name, jobId)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if stop, ok := w.stopChans[key]; ok {
+		close(stop)
+		delete(w.stopChans, key)
+	}
+	delete(w.state, key)
+}
+
+func (w *FlinkJobStatusWatcher) poll(ctx context.Context, application *v1alpha1.FlinkApplication, jobId string) {
+	key := watchKey(application.Namespace, application.Name, jobId)
+	serviceName := getJobManagerServiceName(*application)
+
+	job, err := w.flinkClient.GetJob(ctx, serviceName, jobId)
+	if err != nil {
+		w.pollFromLogs(ctx, application, jobId)
+		return
+	}
+
+	overview, err := w.flinkClient.GetClusterOverview(ctx, serviceName)
+	var taskManagerCount uint
+	if err == nil {
+		taskManagerCount = overview.TaskManagerCount
+	}
+
+	checkpoints, err := w.flinkClient.GetLatestCheckpoint(ctx, serviceName, jobId)
+
+	w.mu.Lock()
+	previous, seen := w.state[key]
+	w.mu.Unlock()
+
+	if !seen || previous.status != job.Status {
+		if seen && previous.everRunning && previous.status != client.FlinkJobRunning && job.Status == client.FlinkJobRunning {
+			w.bus.Publish(JobEvent{Type: JobRestarted, Namespace: application.Namespace, ApplicationName: application.Name, JobId: jobId})
+		}
+		w.bus.Publish(JobEvent{Type: JobStatusChanged, Namespace: application.Namespace, ApplicationName: application.Name, JobId: jobId, Message: string(job.Status)})
+	}
+
+	if seen && job.Status == client.FlinkJobRunning && taskManagerCount > 0 && taskManagerCount < previous.taskManagerCount {
+		w.bus.Publish(JobEvent{Type: TaskManagerLost, Namespace: application.Namespace, ApplicationName: application.Name, JobId: jobId})
+	}
+
+	if seen && err == nil && checkpoints.Latest.Failed != nil && checkpoints.Latest.Failed.Id != previous.lastCheckpointId {
+		w.bus.Publish(JobEvent{Type: CheckpointFailed, Namespace: application.Namespace, ApplicationName: application.Name, JobId: jobId, Message: checkpoints.Latest.Failed.FailureMessage})
+	}
+
+	newState := jobState{
+		status:           job.Status,
+		taskManagerCount: taskManagerCount,
+		lastCheckpointId: previous.lastCheckpointId,
+		everRunning:      previous.everRunning || job.Status == client.FlinkJobRunning,
+	}
+	if err == nil && checkpoints.Latest.Failed != nil {
+		newState.lastCheckpointId = checkpoints.Latest.Failed.Id
+	}
+	w.mu.Lock()
+	w.state[key] = newState
+	w.mu.Unlock()
+}
+
+// pollFromLogs is the fallback used when the REST API is unreachable: it inspects the JobManager
+// pod's logs for markers that indicate the job reached a terminal state, since the job's own
+// status can no longer be queried directly.
+func (w *FlinkJobStatusWatcher) pollFromLogs(ctx context.Context, application *v1alpha1.FlinkApplication, jobId string) {
+	key := watchKey(application.Namespace, application.Name, jobId)
+	appLabels := k8.GetAppLabel(application.Name)
+	logs, err := w.k8Cluster.GetJobManagerPodLogs(ctx, application.Namespace, appLabels)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	previous, seen := w.state[key]
+	w.mu.Unlock()
+
+	for _, marker := range terminalLogMarkers {
+		if strings.Contains(logs, marker) {
+			next := previous
+			next.status = client.FlinkJobFinished
+			w.mu.Lock()
+			w.state[key] = next
+			w.mu.Unlock()
+			if !seen || previous.status != client.FlinkJobFinished {
+				w.bus.Publish(JobEvent{Type: JobStatusChanged, Namespace: application.Namespace, ApplicationName: application.Name, JobId: jobId, Message: string(client.FlinkJobFinished)})
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package flink
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+	flinkclient "github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+)
+
+// fakeFlinkAPI implements flinkclient.FlinkAPIInterface with only the methods the flink package's
+// tests exercise configurable; every other method panics if called.
+type fakeFlinkAPI struct {
+	job           *flinkclient.FlinkJob
+	jobErr        error
+	overview      *flinkclient.ClusterOverviewResponse
+	checkpoints   *flinkclient.CheckpointResponse
+	checkpointErr error
+	uploadResp    *flinkclient.JarUploadResponse
+	uploadErr     error
+	jarPlanResp   *flinkclient.JarPlanResponse
+	jarPlanErr    error
+	submitResp    *flinkclient.SubmitJobResponse
+	submitErr     error
+}
+
+func (f *fakeFlinkAPI) CancelJobWithSavepoint(ctx context.Context, serviceName, jobId string) (string, error) {
+	panic("not implemented")
+}
+func (f *fakeFlinkAPI) SubmitJob(ctx context.Context, serviceName, jarName, savepointPath string, parallelism int32, allowNonRestoredState bool) (*flinkclient.SubmitJobResponse, error) {
+	return f.submitResp, f.submitErr
+}
+func (f *fakeFlinkAPI) CheckSavepointStatus(ctx context.Context, serviceName, jobId, triggerId string) (*flinkclient.SavepointResponse, error) {
+	panic("not implemented")
+}
+func (f *fakeFlinkAPI) GetJobs(ctx context.Context, serviceName string) (*flinkclient.GetJobsResponse, error) {
+	panic("not implemented")
+}
+func (f *fakeFlinkAPI) GetJob(ctx context.Context, serviceName, jobId string) (*flinkclient.FlinkJob, error) {
+	return f.job, f.jobErr
+}
+func (f *fakeFlinkAPI) GetJobConfig(ctx context.Context, serviceName, jobId string) (*flinkclient.JobConfigResponse, error) {
+	panic("not implemented")
+}
+func (f *fakeFlinkAPI) GetClusterOverview(ctx context.Context, serviceName string) (*flinkclient.ClusterOverviewResponse, error) {
+	return f.overview, nil
+}
+func (f *fakeFlinkAPI) GetLatestCheckpoint(ctx context.Context, serviceName, jobId string) (*flinkclient.CheckpointResponse, error) {
+	return f.checkpoints, f.checkpointErr
+}
+func (f *fakeFlinkAPI) UploadJar(ctx context.Context, serviceName, jarName string) (*flinkclient.JarUploadResponse, error) {
+	return f.uploadResp, f.uploadErr
+}
+func (f *fakeFlinkAPI) GetJarPlan(ctx context.Context, serviceName, jarName string, parallelism int32) (*flinkclient.JarPlanResponse, error) {
+	return f.jarPlanResp, f.jarPlanErr
+}
+
+// fakeK8Cluster implements k8.K8ClusterInterface; the watcher tests never exercise its methods
+// since GetJob above never errors, so every method just panics if unexpectedly called.
+type fakeK8Cluster struct{}
+
+func (f *fakeK8Cluster) GetDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) (*appsv1.DeploymentList, error) {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) UpdateK8Object(ctx context.Context, obj k8sclient.Object) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) DeleteDeployments(ctx context.Context, deployments appsv1.DeploymentList) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) IsAllPodsRunning(ctx context.Context, namespace string, labels map[string]string) (bool, error) {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) DeleteDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) DeleteServicesWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) DeleteIngressWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) DeleteConfigMapsWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) GetJobManagerPodLogs(ctx context.Context, namespace string, labels map[string]string) (string, error) {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) GetApplication(ctx context.Context, namespace, name string) (*v1alpha1.FlinkApplication, error) {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) DeleteApplication(ctx context.Context, namespace, name string) error {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) ListApplications(ctx context.Context) (*v1alpha1.FlinkApplicationList, error) {
+	panic("not implemented")
+}
+func (f *fakeK8Cluster) ListFlinkJobs(ctx context.Context, namespace string, labels map[string]string) ([]v1alpha1.FlinkJob, error) {
+	panic("not implemented")
+}
+
+func TestPoll_CheckpointFailedNotFiredOnFirstPoll(t *testing.T) {
+	failed := &flinkclient.CheckpointStatistics{Id: 7, Status: flinkclient.CheckpointFailed, FailureMessage: "boom"}
+	api := &fakeFlinkAPI{
+		job:         &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobRunning},
+		overview:    &flinkclient.ClusterOverviewResponse{TaskManagerCount: 2},
+		checkpoints: &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{Failed: failed}},
+	}
+	bus := NewEventBus()
+	events := bus.Subscribe()
+	w := NewFlinkJobStatusWatcher(api, &fakeK8Cluster{}, bus).(*FlinkJobStatusWatcher)
+	app := &v1alpha1.FlinkApplication{}
+	app.Namespace = "ns"
+	app.Name = "app"
+
+	w.poll(context.Background(), app, "job-1")
+
+	select {
+	case e := <-events:
+		if e.Type == CheckpointFailed {
+			t.Errorf("expected no CheckpointFailed event on the first poll, got one")
+		}
+	default:
+	}
+
+	key := watchKey(app.Namespace, app.Name, "job-1")
+	if w.state[key].lastCheckpointId != 7 {
+		t.Errorf("expected the first poll to record lastCheckpointId=7 as a baseline, got %d", w.state[key].lastCheckpointId)
+	}
+}
+
+func TestPoll_CheckpointFailedDoesNotRefireAfterSuccessfulCheckpoint(t *testing.T) {
+	failed := &flinkclient.CheckpointStatistics{Id: 7, Status: flinkclient.CheckpointFailed, FailureMessage: "boom"}
+	api := &fakeFlinkAPI{
+		job:         &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobRunning},
+		overview:    &flinkclient.ClusterOverviewResponse{TaskManagerCount: 2},
+		checkpoints: &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{Failed: failed}},
+	}
+	bus := NewEventBus()
+	events := bus.Subscribe()
+	w := NewFlinkJobStatusWatcher(api, &fakeK8Cluster{}, bus).(*FlinkJobStatusWatcher)
+	app := &v1alpha1.FlinkApplication{}
+	app.Namespace = "ns"
+	app.Name = "app"
+
+	// First poll establishes the failed checkpoint as a seen baseline.
+	w.poll(context.Background(), app, "job-1")
+	drain(events)
+
+	// A later poll reports a successful checkpoint (Failed == nil); lastCheckpointId must be
+	// carried forward rather than reset to 0.
+	api.checkpoints = &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{
+		Completed: &flinkclient.CheckpointStatistics{Id: 8, Status: flinkclient.CheckpointCompleted},
+	}}
+	w.poll(context.Background(), app, "job-1")
+	drain(events)
+
+	// A third poll reports the exact same failed checkpoint id 7 again (e.g. the JobManager
+	// hasn't triggered a new checkpoint attempt yet). Since it was already seen, it must not
+	// re-fire CheckpointFailed.
+	api.checkpoints = &flinkclient.CheckpointResponse{Latest: flinkclient.LatestCheckpoints{Failed: failed}}
+	w.poll(context.Background(), app, "job-1")
+
+	select {
+	case e := <-events:
+		if e.Type == CheckpointFailed {
+			t.Errorf("expected the already-seen failed checkpoint id not to refire CheckpointFailed")
+		}
+	default:
+	}
+}
+
+func TestPoll_DistinctJobIdsOnSameApplicationTrackIndependentState(t *testing.T) {
+	api := &fakeFlinkAPI{
+		job:      &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobRunning},
+		overview: &flinkclient.ClusterOverviewResponse{TaskManagerCount: 2},
+	}
+	w := NewFlinkJobStatusWatcher(api, &fakeK8Cluster{}, NewEventBus()).(*FlinkJobStatusWatcher)
+	app := &v1alpha1.FlinkApplication{}
+	app.Namespace = "ns"
+	app.Name = "app"
+
+	w.poll(context.Background(), app, "job-1")
+	api.job = &flinkclient.FlinkJob{JobId: "job-2", Status: flinkclient.FlinkJobFailed}
+	w.poll(context.Background(), app, "job-2")
+
+	if w.state[watchKey("ns", "app", "job-1")].status != flinkclient.FlinkJobRunning {
+		t.Errorf("expected job-1's state to be unaffected by polling job-2 on the same application")
+	}
+	if w.state[watchKey("ns", "app", "job-2")].status != flinkclient.FlinkJobFailed {
+		t.Errorf("expected job-2 to have its own tracked state, got %+v", w.state[watchKey("ns", "app", "job-2")])
+	}
+}
+
+func TestPoll_JobRestartedDoesNotFireOnFirstCreatedToRunningTransition(t *testing.T) {
+	api := &fakeFlinkAPI{
+		job:      &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobCreated},
+		overview: &flinkclient.ClusterOverviewResponse{TaskManagerCount: 2},
+	}
+	bus := NewEventBus()
+	events := bus.Subscribe()
+	w := NewFlinkJobStatusWatcher(api, &fakeK8Cluster{}, bus).(*FlinkJobStatusWatcher)
+	app := &v1alpha1.FlinkApplication{}
+	app.Namespace = "ns"
+	app.Name = "app"
+
+	w.poll(context.Background(), app, "job-1")
+	drain(events)
+
+	api.job = &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobRunning}
+	w.poll(context.Background(), app, "job-1")
+
+	for {
+		select {
+		case e := <-events:
+			if e.Type == JobRestarted {
+				t.Errorf("expected a job's first CREATED -> RUNNING transition not to fire JobRestarted")
+			}
+			continue
+		default:
+		}
+		break
+	}
+}
+
+func TestPoll_JobRestartedFiresAfterActuallyHavingRunBefore(t *testing.T) {
+	api := &fakeFlinkAPI{
+		job:      &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobRunning},
+		overview: &flinkclient.ClusterOverviewResponse{TaskManagerCount: 2},
+	}
+	bus := NewEventBus()
+	events := bus.Subscribe()
+	w := NewFlinkJobStatusWatcher(api, &fakeK8Cluster{}, bus).(*FlinkJobStatusWatcher)
+	app := &v1alpha1.FlinkApplication{}
+	app.Namespace = "ns"
+	app.Name = "app"
+
+	w.poll(context.Background(), app, "job-1")
+	drain(events)
+
+	api.job = &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobFailed}
+	w.poll(context.Background(), app, "job-1")
+	drain(events)
+
+	api.job = &flinkclient.FlinkJob{JobId: "job-1", Status: flinkclient.FlinkJobRunning}
+	w.poll(context.Background(), app, "job-1")
+
+	found := false
+	for {
+		select {
+		case e := <-events:
+			if e.Type == JobRestarted {
+				found = true
+			}
+			continue
+		default:
+		}
+		break
+	}
+	if !found {
+		t.Errorf("expected JobRestarted to fire when a job that had already run goes RUNNING again")
+	}
+}
+
+func TestStopWatching_OnlyStopsTheNamedJob(t *testing.T) {
+	w := NewFlinkJobStatusWatcher(&fakeFlinkAPI{}, &fakeK8Cluster{}, NewEventBus()).(*FlinkJobStatusWatcher)
+	key1 := watchKey("ns", "app", "job-1")
+	key2 := watchKey("ns", "app", "job-2")
+	w.stopChans[key1] = make(chan struct{})
+	w.stopChans[key2] = make(chan struct{})
+
+	w.StopWatching("ns", "app", "job-1")
+
+	if _, ok := w.stopChans[key1]; ok {
+		t.Errorf("expected job-1's stop channel to be removed")
+	}
+	if _, ok := w.stopChans[key2]; !ok {
+		t.Errorf("expected job-2's stop channel to be left untouched by stopping job-1")
+	}
+}
+
+// fakeK8ClusterWithLogs returns logs for GetJobManagerPodLogs and panics on every other method.
+type fakeK8ClusterWithLogs struct {
+	fakeK8Cluster
+	logs string
+}
+
+func (f *fakeK8ClusterWithLogs) GetJobManagerPodLogs(ctx context.Context, namespace string, labels map[string]string) (string, error) {
+	return f.logs, nil
+}
+
+func TestPollFromLogs_DoesNotRepublishOnceAlreadyFinished(t *testing.T) {
+	k8Cluster := &fakeK8ClusterWithLogs{logs: "Job has been finished"}
+	bus := NewEventBus()
+	events := bus.Subscribe()
+	w := NewFlinkJobStatusWatcher(&fakeFlinkAPI{}, k8Cluster, bus).(*FlinkJobStatusWatcher)
+	app := &v1alpha1.FlinkApplication{}
+	app.Namespace = "ns"
+	app.Name = "app"
+
+	w.pollFromLogs(context.Background(), app, "job-1")
+	if e := <-events; e.Type != JobStatusChanged {
+		t.Fatalf("expected the first terminal-marker poll to publish JobStatusChanged, got %v", e.Type)
+	}
+
+	w.pollFromLogs(context.Background(), app, "job-1")
+	select {
+	case e := <-events:
+		t.Errorf("expected no further JobStatusChanged once already recorded as Finished, got %v", e.Type)
+	default:
+	}
+}
+
+func drain(ch <-chan JobEvent) {
+	for {
+		select {
+		case <-ch:
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package gc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+	"github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+	"github.com/lyft/flinkk8soperator/pkg/controller/k8"
+)
+
+// FinishedJobStatuses are the terminal job states that make an Application eligible for garbage
+// collection once its TTL has elapsed.
+var FinishedJobStatuses = map[client.FlinkJobStatus]bool{
+	client.FlinkJobFinished: true,
+	client.FlinkJobCanceled: true,
+	client.FlinkJobFailed:   true,
+}
+
+// Finalizer is added to every FlinkApplication's ObjectMeta.Finalizers the first time the
+// GarbageCollector sees it marked eligible, and removed only once its owned deployments,
+// services, ingress and configmaps have all been deleted. This guarantees the CR itself is never
+// reaped by Kubernetes until its child resources are gone, while leaving any other finalizer
+// another controller has placed on the Application untouched.
+const Finalizer = "gc.finalizer.flink.k8s.io"
+
+// gcWorkers is the number of keys processed concurrently. Each key's own child-resource deletes
+// already run in parallel (see process); this additionally lets independent Applications be
+// collected without queueing behind one another.
+const gcWorkers = 4
+
+// ApplicationGetter is the subset of the controller's cache the GarbageCollector needs to look
+// up an Application by name; it is satisfied by the controller-runtime client/informer used by
+// the main reconciler.
+type ApplicationGetter interface {
+	GetApplication(ctx context.Context, namespace, name string) (*v1alpha1.FlinkApplication, error)
+}
+
+// GarbageCollectorInterface deletes FlinkApplications (and the k8 resources they own) once they
+// have been terminal for longer than Spec.TTLSecondsAfterFinished.
+type GarbageCollectorInterface interface {
+	// Run starts gcWorkers GC workers and blocks until ctx is cancelled.
+	Run(ctx context.Context)
+
+	// Enqueue schedules namespace/name for a TTL check, re-enqueuing with the correct
+	// remaining-TTL delay rather than the caller having to poll.
+	Enqueue(namespace, name string, finishedAt time.Time, ttlSeconds int64)
+}
+
+// NewGarbageCollector constructs a GarbageCollector backed by the given k8 client and
+// application getter.
+func NewGarbageCollector(k8Cluster k8.K8ClusterInterface, apps ApplicationGetter) GarbageCollectorInterface {
+	return &GarbageCollector{
+		k8Cluster: k8Cluster,
+		apps:      apps,
+		queue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+}
+
+type gcKey struct {
+	namespace string
+	name      string
+}
+
+type GarbageCollector struct {
+	k8Cluster k8.K8ClusterInterface
+	apps      ApplicationGetter
+	queue     workqueue.RateLimitingInterface
+}
+
+func (g *GarbageCollector) Enqueue(namespace, name string, finishedAt time.Time, ttlSeconds int64) {
+	remaining := time.Until(finishedAt.Add(time.Duration(ttlSeconds) * time.Second))
+	if remaining < 0 {
+		remaining = 0
+	}
+	g.queue.AddAfter(gcKey{namespace: namespace, name: name}, remaining)
+}
+
+// Run starts gcWorkers workers pulling keys off the delaying queue as their TTL expires and
+// deleting the Application's owned resources. It honors finalizers on the FlinkApplication: the
+// GC's own Finalizer is added before any child resource is touched and removed only once they're
+// all gone, so the CR always outlives its children; any other finalizer already present is left
+// untouched.
+func (g *GarbageCollector) Run(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		g.queue.ShutDown()
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < gcWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				key, shutdown := g.queue.Get()
+				if shutdown {
+					return
+				}
+				g.processAndRetry(ctx, key.(gcKey))
+				g.queue.Done(key)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (g *GarbageCollector) processAndRetry(ctx context.Context, key gcKey) {
+	if err := g.process(ctx, key); err != nil {
+		g.queue.AddRateLimited(key)
+		return
+	}
+	g.queue.Forget(key)
+}
+
+func (g *GarbageCollector) process(ctx context.Context, key gcKey) error {
+	app, err := g.apps.GetApplication(ctx, key.namespace, key.name)
+	if err != nil || app == nil {
+		// Application no longer exists; nothing to collect.
+		return nil
+	}
+	if !g.isEligible(app) {
+		return nil
+	}
+
+	if !hasFinalizer(app, Finalizer) {
+		app.Finalizers = append(app.Finalizers, Finalizer)
+		if err := g.k8Cluster.UpdateK8Object(ctx, app); err != nil {
+			return err
+		}
+	}
+
+	appLabels := k8.GetAppLabel(app.Name)
+	deletes := []func(context.Context, string, map[string]string) error{
+		g.k8Cluster.DeleteDeploymentsWithLabel,
+		g.k8Cluster.DeleteServicesWithLabel,
+		g.k8Cluster.DeleteIngressWithLabel,
+		g.k8Cluster.DeleteConfigMapsWithLabel,
+	}
+	errs := make([]error, len(deletes))
+	var wg sync.WaitGroup
+	for i, del := range deletes {
+		wg.Add(1)
+		go func(i int, del func(context.Context, string, map[string]string) error) {
+			defer wg.Done()
+			errs[i] = del(ctx, app.Namespace, appLabels)
+		}(i, del)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	app.Finalizers = removeFinalizer(app.Finalizers, Finalizer)
+	if err := g.k8Cluster.UpdateK8Object(ctx, app); err != nil {
+		return err
+	}
+	return g.k8Cluster.DeleteApplication(ctx, app.Namespace, app.Name)
+}
+
+func (g *GarbageCollector) isEligible(app *v1alpha1.FlinkApplication) bool {
+	if app.Spec.TTLSecondsAfterFinished == nil {
+		return false
+	}
+	status := app.Status.JobStatus.Status
+	if !FinishedJobStatuses[client.FlinkJobStatus(status)] {
+		return false
+	}
+	finishedAt := app.Status.JobStatus.LastTransitionTime.Time
+	deadline := finishedAt.Add(time.Duration(*app.Spec.TTLSecondsAfterFinished) * time.Second)
+	return !time.Now().Before(deadline)
+}
+
+func hasFinalizer(app *v1alpha1.FlinkApplication, finalizer string) bool {
+	for _, f := range app.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string, finalizer string) []string {
+	out := finalizers[:0]
+	for _, f := range finalizers {
+		if f != finalizer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
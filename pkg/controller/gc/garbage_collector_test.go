@@ -0,0 +1,95 @@
+package gc
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+	"github.com/lyft/flinkk8soperator/pkg/controller/flink/client"
+)
+
+func ttl(seconds int64) *int64 {
+	return &seconds
+}
+
+func TestIsEligible_NoTTLConfigured(t *testing.T) {
+	g := &GarbageCollector{}
+	app := &v1alpha1.FlinkApplication{
+		Status: v1alpha1.FlinkApplicationStatus{
+			JobStatus: v1alpha1.FlinkJobStatusInfo{
+				Status:             string(client.FlinkJobFinished),
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		},
+	}
+	if g.isEligible(app) {
+		t.Errorf("expected application with no TTLSecondsAfterFinished to be ineligible")
+	}
+}
+
+func TestIsEligible_JobNotTerminal(t *testing.T) {
+	g := &GarbageCollector{}
+	app := &v1alpha1.FlinkApplication{
+		Spec: v1alpha1.FlinkApplicationSpec{TTLSecondsAfterFinished: ttl(60)},
+		Status: v1alpha1.FlinkApplicationStatus{
+			JobStatus: v1alpha1.FlinkJobStatusInfo{
+				Status:             string(client.FlinkJobRunning),
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		},
+	}
+	if g.isEligible(app) {
+		t.Errorf("expected a RUNNING job to be ineligible regardless of TTL")
+	}
+}
+
+func TestIsEligible_TTLNotYetElapsed(t *testing.T) {
+	g := &GarbageCollector{}
+	app := &v1alpha1.FlinkApplication{
+		Spec: v1alpha1.FlinkApplicationSpec{TTLSecondsAfterFinished: ttl(3600)},
+		Status: v1alpha1.FlinkApplicationStatus{
+			JobStatus: v1alpha1.FlinkJobStatusInfo{
+				Status:             string(client.FlinkJobFinished),
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+		},
+	}
+	if g.isEligible(app) {
+		t.Errorf("expected application finished a minute ago with a one hour TTL to be ineligible")
+	}
+}
+
+func TestIsEligible_TTLElapsed(t *testing.T) {
+	g := &GarbageCollector{}
+	app := &v1alpha1.FlinkApplication{
+		Spec: v1alpha1.FlinkApplicationSpec{TTLSecondsAfterFinished: ttl(60)},
+		Status: v1alpha1.FlinkApplicationStatus{
+			JobStatus: v1alpha1.FlinkJobStatusInfo{
+				Status:             string(client.FlinkJobCanceled),
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		},
+	}
+	if !g.isEligible(app) {
+		t.Errorf("expected application finished an hour ago with a one minute TTL to be eligible")
+	}
+}
+
+func TestHasFinalizerAndRemoveFinalizer(t *testing.T) {
+	app := &v1alpha1.FlinkApplication{}
+	app.Finalizers = []string{"other.finalizer.io", Finalizer}
+
+	if !hasFinalizer(app, Finalizer) {
+		t.Fatalf("expected hasFinalizer to find %s", Finalizer)
+	}
+
+	app.Finalizers = removeFinalizer(app.Finalizers, Finalizer)
+	if hasFinalizer(app, Finalizer) {
+		t.Errorf("expected %s to be removed", Finalizer)
+	}
+	if len(app.Finalizers) != 1 || app.Finalizers[0] != "other.finalizer.io" {
+		t.Errorf("expected other finalizers to be left untouched, got %v", app.Finalizers)
+	}
+}
@@ -0,0 +1,54 @@
+package ha
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOwnsShard_SingleShardOwnsEverything(t *testing.T) {
+	obj := &metav1.ObjectMeta{Name: "app-1"}
+	if !OwnsShard(obj, 0, 1) {
+		t.Errorf("expected shard 0 of 1 to own every object")
+	}
+}
+
+func TestOwnsShard_UnlabeledObjectOwnedByShardZero(t *testing.T) {
+	obj := &metav1.ObjectMeta{Name: "app-1"}
+	if !OwnsShard(obj, 0, 3) {
+		t.Errorf("expected unlabeled object to be owned by shard 0")
+	}
+	if OwnsShard(obj, 1, 3) {
+		t.Errorf("expected unlabeled object not to be owned by shard 1")
+	}
+}
+
+func TestOwnsShard_LabeledObjectOwnedByExactlyOneShard(t *testing.T) {
+	const totalShards = 5
+	obj := &metav1.ObjectMeta{
+		Name:   "app-1",
+		Labels: map[string]string{ShardLabelKey: "some-shard-key"},
+	}
+	owners := 0
+	for shard := 0; shard < totalShards; shard++ {
+		if OwnsShard(obj, shard, totalShards) {
+			owners++
+		}
+	}
+	if owners != 1 {
+		t.Errorf("expected exactly one shard to own a labeled object, got %d", owners)
+	}
+}
+
+func TestOwnsShard_IsStableForSameLabel(t *testing.T) {
+	obj := &metav1.ObjectMeta{
+		Name:   "app-1",
+		Labels: map[string]string{ShardLabelKey: "stable-key"},
+	}
+	first := OwnsShard(obj, 2, 4)
+	for i := 0; i < 5; i++ {
+		if OwnsShard(obj, 2, 4) != first {
+			t.Errorf("expected OwnsShard to be deterministic for the same label/shard/totalShards")
+		}
+	}
+}
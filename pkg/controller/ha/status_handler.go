@@ -0,0 +1,29 @@
+package ha
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// statusResponse is the payload served at /status.
+type statusResponse struct {
+	Identity    string `json:"identity"`
+	IsLeader    bool   `json:"isLeader"`
+	ShardID     int    `json:"shardId"`
+	TotalShards int    `json:"totalShards"`
+}
+
+// NewStatusHandler returns an http.Handler exposing this replica's leader identity and shard
+// assignment at /status, so operators can inspect which replica is active without reading logs.
+func NewStatusHandler(elector LeaderElectorInterface, shardID, totalShards int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := statusResponse{
+			Identity:    elector.Identity(),
+			IsLeader:    elector.IsLeader(),
+			ShardID:     shardID,
+			TotalShards: totalShards,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,322 @@
+package k8
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lyft/flinkk8soperator/pkg/apis/app/v1alpha1"
+)
+
+const appLabelKey = "flink-app"
+const imageLabelKey = "flink-app-hash"
+
+// GetAppLabel returns the label selector used to find every k8 resource (deployments, services,
+// ingress, configmaps) owned by a FlinkApplication.
+func GetAppLabel(appName string) map[string]string {
+	return map[string]string{appLabelKey: appName}
+}
+
+// GetImageKey derives a short, stable label value from a container image reference.
+func GetImageKey(image string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(image))
+	return fmt.Sprintf("%x", h.Sum32())
+}
+
+// GetImageLabel returns the label selector used to find deployments running a given image.
+func GetImageLabel(imageKey string) map[string]string {
+	return map[string]string{imageLabelKey: imageKey}
+}
+
+// MatchDeploymentsByLabel splits list into deployments matching imageLabel (current) and those
+// that don't (old, i.e. left over from a previous spec).
+func MatchDeploymentsByLabel(list appsv1.DeploymentList, imageLabel map[string]string) ([]appsv1.Deployment, []appsv1.Deployment) {
+	var current, old []appsv1.Deployment
+	for _, deployment := range list.Items {
+		matches := true
+		for k, v := range imageLabel {
+			if deployment.Labels[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			current = append(current, deployment)
+		} else {
+			old = append(old, deployment)
+		}
+	}
+	return current, old
+}
+
+// K8ClusterInterface is the operator's view of the Kubernetes API: the child resources it
+// manages for each FlinkApplication (deployments, services, ingress, configmaps, pods) plus CRUD
+// on the FlinkApplication/FlinkJob custom resources themselves.
+type K8ClusterInterface interface {
+	GetDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) (*appsv1.DeploymentList, error)
+	UpdateK8Object(ctx context.Context, obj client.Object) error
+	DeleteDeployments(ctx context.Context, deployments appsv1.DeploymentList) error
+	IsAllPodsRunning(ctx context.Context, namespace string, labels map[string]string) (bool, error)
+
+	// DeleteDeploymentsWithLabel, DeleteServicesWithLabel, DeleteIngressWithLabel and
+	// DeleteConfigMapsWithLabel delete every resource of their kind matching labels in
+	// namespace; used by the GarbageCollector to tear down everything a finished Application
+	// owns.
+	DeleteDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) error
+	DeleteServicesWithLabel(ctx context.Context, namespace string, labels map[string]string) error
+	DeleteIngressWithLabel(ctx context.Context, namespace string, labels map[string]string) error
+	DeleteConfigMapsWithLabel(ctx context.Context, namespace string, labels map[string]string) error
+
+	// GetJobManagerPodLogs returns the concatenated recent logs of the JobManager pod(s)
+	// matching labels, used by FlinkJobStatusWatcher as a fallback when the REST API is
+	// unreachable.
+	GetJobManagerPodLogs(ctx context.Context, namespace string, labels map[string]string) (string, error)
+
+	GetApplication(ctx context.Context, namespace, name string) (*v1alpha1.FlinkApplication, error)
+	// DeleteApplication starts deletion of the FlinkApplication CR itself (setting its deletion
+	// timestamp); any finalizers present on the object will block its actual removal until
+	// they're cleared.
+	DeleteApplication(ctx context.Context, namespace, name string) error
+	ListApplications(ctx context.Context) (*v1alpha1.FlinkApplicationList, error)
+	ListFlinkJobs(ctx context.Context, namespace string, labels map[string]string) ([]v1alpha1.FlinkJob, error)
+}
+
+// NewK8Cluster builds a K8ClusterInterface from the ambient kubeconfig (in-cluster config when
+// running as a pod, falling back to the default kubeconfig loading rules otherwise).
+func NewK8Cluster() K8ClusterInterface {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(),
+			&clientcmd.ConfigOverrides{},
+		).ClientConfig()
+	}
+	if err != nil {
+		// Defer the error to first use; every method below will fail with a clear error
+		// rather than the process panicking at startup.
+		return &K8Cluster{configErr: err}
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return &K8Cluster{configErr: err}
+	}
+	ctrlClient, err := client.New(config, client.Options{Scheme: newScheme()})
+	if err != nil {
+		return &K8Cluster{configErr: err}
+	}
+	return &K8Cluster{kubeClient: kubeClient, ctrlClient: ctrlClient}
+}
+
+// newScheme returns the built-in Kubernetes scheme extended with the FlinkApplication/FlinkJob
+// CRD types, so ctrlClient can Get/List/Delete them alongside core and apps/v1 objects.
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+type K8Cluster struct {
+	kubeClient kubernetes.Interface
+	ctrlClient client.Client
+	configErr  error
+}
+
+func toListOptions(labels map[string]string) metav1.ListOptions {
+	return metav1.ListOptions{LabelSelector: toSelector(labels)}
+}
+
+func toSelector(labels map[string]string) string {
+	selector := ""
+	for k, v := range labels {
+		if selector != "" {
+			selector += ","
+		}
+		selector += fmt.Sprintf("%s=%s", k, v)
+	}
+	return selector
+}
+
+func (k *K8Cluster) GetDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) (*appsv1.DeploymentList, error) {
+	if k.configErr != nil {
+		return nil, k.configErr
+	}
+	return k.kubeClient.AppsV1().Deployments(namespace).List(ctx, toListOptions(labels))
+}
+
+func (k *K8Cluster) UpdateK8Object(ctx context.Context, obj client.Object) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	return k.ctrlClient.Update(ctx, obj)
+}
+
+func (k *K8Cluster) DeleteDeployments(ctx context.Context, deployments appsv1.DeploymentList) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	for _, deployment := range deployments.Items {
+		if err := k.kubeClient.AppsV1().Deployments(deployment.Namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *K8Cluster) IsAllPodsRunning(ctx context.Context, namespace string, labels map[string]string) (bool, error) {
+	if k.configErr != nil {
+		return false, k.configErr
+	}
+	pods, err := k.kubeClient.CoreV1().Pods(namespace).List(ctx, toListOptions(labels))
+	if err != nil {
+		return false, err
+	}
+	if len(pods.Items) == 0 {
+		return false, nil
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (k *K8Cluster) DeleteDeploymentsWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	return k.kubeClient.AppsV1().Deployments(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, toListOptions(labels))
+}
+
+func (k *K8Cluster) DeleteServicesWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	services, err := k.kubeClient.CoreV1().Services(namespace).List(ctx, toListOptions(labels))
+	if err != nil {
+		return err
+	}
+	for _, svc := range services.Items {
+		if err := k.kubeClient.CoreV1().Services(namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *K8Cluster) DeleteIngressWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	ingresses, err := k.kubeClient.ExtensionsV1beta1().Ingresses(namespace).List(ctx, toListOptions(labels))
+	if err != nil {
+		return err
+	}
+	for _, ingress := range ingresses.Items {
+		if err := k.kubeClient.ExtensionsV1beta1().Ingresses(namespace).Delete(ctx, ingress.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *K8Cluster) DeleteConfigMapsWithLabel(ctx context.Context, namespace string, labels map[string]string) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	return k.kubeClient.CoreV1().ConfigMaps(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, toListOptions(labels))
+}
+
+func (k *K8Cluster) GetJobManagerPodLogs(ctx context.Context, namespace string, labels map[string]string) (string, error) {
+	if k.configErr != nil {
+		return "", k.configErr
+	}
+	pods, err := k.kubeClient.CoreV1().Pods(namespace).List(ctx, toListOptions(labels))
+	if err != nil {
+		return "", err
+	}
+	var logs string
+	for _, pod := range pods.Items {
+		req := k.kubeClient.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{TailLines: int64Ptr(200)})
+		stream, err := req.Stream(ctx)
+		if err != nil {
+			continue
+		}
+		buf := make([]byte, 4096)
+		for {
+			n, readErr := stream.Read(buf)
+			if n > 0 {
+				logs += string(buf[:n])
+			}
+			if readErr != nil {
+				break
+			}
+		}
+		stream.Close()
+	}
+	return logs, nil
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func (k *K8Cluster) GetApplication(ctx context.Context, namespace, name string) (*v1alpha1.FlinkApplication, error) {
+	if k.configErr != nil {
+		return nil, k.configErr
+	}
+	app := &v1alpha1.FlinkApplication{}
+	if err := k.ctrlClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func (k *K8Cluster) DeleteApplication(ctx context.Context, namespace, name string) error {
+	if k.configErr != nil {
+		return k.configErr
+	}
+	app := &v1alpha1.FlinkApplication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+	return k.ctrlClient.Delete(ctx, app)
+}
+
+func (k *K8Cluster) ListApplications(ctx context.Context) (*v1alpha1.FlinkApplicationList, error) {
+	if k.configErr != nil {
+		return nil, k.configErr
+	}
+	list := &v1alpha1.FlinkApplicationList{}
+	if err := k.ctrlClient.List(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (k *K8Cluster) ListFlinkJobs(ctx context.Context, namespace string, labels map[string]string) ([]v1alpha1.FlinkJob, error) {
+	if k.configErr != nil {
+		return nil, k.configErr
+	}
+	list := &v1alpha1.FlinkJobList{}
+	if err := k.ctrlClient.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}